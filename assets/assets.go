@@ -0,0 +1,10 @@
+// Package assets bundles the fonts and images used by the examples into the
+// programs themselves, so they can be run from any directory.
+package assets
+
+import "embed"
+
+// Files holds every asset in this directory.
+//
+//go:embed *.png *.ttf
+var Files embed.FS
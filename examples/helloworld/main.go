@@ -9,7 +9,7 @@ import (
 )
 
 func init() {
-	engine.Init("Hello World!", 1200, 1024)
+	engine.Abort(engine.Init("Hello World!", 1200, 1024))
 	engine.Debug(true)
 	engine.Setup(func(world ecs.World) bool {
 		return true
@@ -3,9 +3,10 @@ package main
 import (
 	"fmt"
 
+	"github.com/jordanbrauer/hallucinator/assets"
 	"github.com/jordanbrauer/hallucinator/pkg/ecs"
 	"github.com/jordanbrauer/hallucinator/pkg/engine"
-	"github.com/veandco/go-sdl2/sdl"
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
 )
 
 const (
@@ -14,8 +15,9 @@ const (
 )
 
 func init() {
-	engine.Init("Pong", windowWidth, windowHeight)
-	engine.Pixels()
+	engine.Mount(assets.Files)
+	engine.Abort(engine.Init("Pong", windowWidth, windowHeight))
+	engine.Abort(engine.Pixels())
 	engine.Debug(true)
 
 	var rigidBody string = ecs.RigidBody{}.Name()
@@ -28,6 +30,21 @@ func init() {
 		Height: 32 * 4,
 	}
 
+	var actions = engine.Actions()
+
+	actions.Bind(
+		"move_x",
+		engine.BindKey(engine.KeyLeft).Scaled(-1),
+		engine.BindKey(engine.KeyRight),
+		engine.BindGamepadAxis(engine.GamepadLeftX),
+	)
+	actions.Bind(
+		"move_y",
+		engine.BindKey(engine.KeyUp).Scaled(-1),
+		engine.BindKey(engine.KeyDown),
+		engine.BindGamepadAxis(engine.GamepadLeftY),
+	)
+
 	engine.Setup(func(world ecs.World) bool {
 		world.RegisterComponent(rigidBody)
 		world.RegisterComponent(transform)
@@ -39,97 +56,100 @@ func init() {
 		world.RegisterSystem(new(rendering), transform, colour)
 		world.RegisterSystem(new(controller), controllerInput, transform, rigidBody)
 		world.RegisterSystem(new(collision), rigidBody, transform)
+		engine.Abort(world.AddPlugins(ecs.CreateSpatialIndex(64)))
 
 		var player = world.CreateEntity()
 
-		world.AttachComponent(player, new(input))
-		world.AttachComponent(player, new(ecs.RigidBody))
-		world.AttachComponent(player, &ecs.Colour{Red: 255, Green: 255, Blue: 255})
-		world.AttachComponent(player, &ecs.Transform{
-			Dimensions: paddle,
-			Position: ecs.Position{
-				VectorFloat32: ecs.VectorFloat32{
-					X: 64,
-					Y: float32((windowHeight / 2) - (32 * 2)),
+		world.AttachBundle(player, ecs.Bundle{
+			new(input),
+			new(ecs.RigidBody),
+			&ecs.Colour{Red: 255, Green: 255, Blue: 255},
+			&ecs.Transform{
+				Dimensions: paddle,
+				Position: ecs.Position{
+					Vec3: maths.Vec3{
+						X: 64,
+						Y: float32((windowHeight / 2) - (32 * 2)),
+					},
 				},
 			},
 		})
 
 		var computer = world.CreateEntity()
 
-		world.AttachComponent(computer, new(ecs.RigidBody))
-		world.AttachComponent(computer, &ecs.Colour{Red: 255, Green: 255, Blue: 255})
-		world.AttachComponent(computer, &ecs.Transform{
-			Dimensions: paddle,
-			Position: ecs.Position{
-				VectorFloat32: ecs.VectorFloat32{
-					X: float32(windowWidth - (64 + 32)),
-					Y: float32((windowHeight / 2) - (32 * 2)),
+		world.AttachBundle(computer, ecs.Bundle{
+			new(ecs.RigidBody),
+			&ecs.Colour{Red: 255, Green: 255, Blue: 255},
+			&ecs.Transform{
+				Dimensions: paddle,
+				Position: ecs.Position{
+					Vec3: maths.Vec3{
+						X: float32(windowWidth - (64 + 32)),
+						Y: float32((windowHeight / 2) - (32 * 2)),
+					},
 				},
 			},
 		})
 
 		var ball = world.CreateEntity()
 
-		world.AttachComponent(ball, &ecs.RigidBody{
-			Velocity: ecs.VectorFloat32{
-				X: 300,
-				Y: 300,
-			},
-		})
-		world.AttachComponent(ball, new(dynamic))
-		world.AttachComponent(ball, &ecs.Colour{Red: 255, Green: 255, Blue: 255})
-		world.AttachComponent(ball, &ecs.Transform{
-			Dimensions: ecs.Dimensions{
-				Width:  32,
-				Height: 32,
-				Radius: 0,
+		world.AttachBundle(ball, ecs.Bundle{
+			&ecs.RigidBody{
+				Velocity: maths.Vec3{
+					X: 300,
+					Y: 300,
+				},
 			},
-			Position: ecs.Position{
-				VectorFloat32: ecs.VectorFloat32{
-					X: float32(windowWidth / 2),
-					Y: float32(windowHeight / 2),
+			new(dynamic),
+			&ecs.Colour{Red: 255, Green: 255, Blue: 255},
+			&ecs.Transform{
+				Dimensions: ecs.Dimensions{
+					Width:  32,
+					Height: 32,
+					Radius: 0,
+				},
+				Position: ecs.Position{
+					Vec3: maths.Vec3{
+						X: float32(windowWidth / 2),
+						Y: float32(windowHeight / 2),
+					},
 				},
 			},
 		})
 
 		return true
 	})
+	engine.FixedUpdate(func(world ecs.World) bool {
+		var dt = engine.FixedElapsed()
+
+		world.Update(controller{}.Name(), dt)
+		world.Update(physics{}.Name(), dt)
+		world.Update(collision{}.Name(), dt)
+		world.Update(camera{}.Name(), dt)
+
+		return true
+	})
 	engine.Teardown(func(world ecs.World) bool {
 		return true
 	})
 }
 
 func main() {
-	var font = engine.LoadFont("./assets/JetBrainsMono-Regular.ttf", 14)
-	var texture *sdl.Texture
+	var font, err = engine.LoadFont("JetBrainsMono-Regular.ttf", 14)
 
-	engine.Run(func(world ecs.World) bool {
-		var dt = engine.FrameElapsed()
+	engine.Fatal(err)
 
-		world.Update(controller{}.Name(), dt)
-		world.Update(physics{}.Name(), dt)
-		world.Update(collision{}.Name(), dt)
-		world.Update(camera{}.Name(), dt)
-		world.Update(rendering{}.Name(), dt)
+	engine.Run(func(world ecs.World) bool {
+		world.Update(rendering{}.Name(), engine.FrameElapsed())
 
 		var fps = engine.FramesPerSecond()
 		var debug = fmt.Sprintf("FPS: %d | Frame Elapsed: %f | Entities: %d", fps.Count, fps.Elapsed, world.Entities())
-		texture = engine.TexturizeString(
-			font,
-			debug,
-		)
-		var _, _, width, height, err = texture.Query()
-
-		engine.Abort(err)
-		engine.Render(texture, nil, &sdl.Rect{X: 15, Y: 15, W: width, H: height})
+
+		engine.Abort(engine.DrawText(font, debug, 15, 15, engine.White()))
 		fmt.Print(fmt.Sprintf("%s\r", debug))
 
 		return true
 	})
-
-	// texture.Destroy()
-	// font.Close()
 }
 
 //
@@ -188,7 +208,7 @@ func (system *rendering) Update(dt float32) {
 		}
 	}
 
-	engine.Present()
+	engine.Abort(engine.Present())
 }
 
 type collision struct {
@@ -202,13 +222,15 @@ func (collision) Name() string {
 func (system *collision) Update(dt float32) {
 	var entities = system.Entities()
 	var collisions = map[ecs.Entity]ecs.Entity{}
+	var index = system.World().Resource(ecs.SpatialIndex{}.Name()).(*ecs.SpatialIndex)
 
 	for _, entity := range entities {
 		// var body = system.Component(entity, ecs.RigidBody{}.Name()).(*ecs.RigidBody)
 		var xform = system.Component(entity, ecs.Transform{}.Name()).(*ecs.Transform)
+		var nearby = index.Rect(xform.Position.X, xform.Position.Y, xform.Width, xform.Height)
 
-		for _, other := range entities {
-			if entity == other {
+		for _, other := range nearby {
+			if entity == other || !system.Subscribed(other) {
 				continue
 			}
 
@@ -220,24 +242,26 @@ func (system *collision) Update(dt float32) {
 				(xform.Position.Y+xform.Height) >= otherXform.Position.Y &&
 				(xform.Position.Y) <= (otherXform.Position.Y+otherXform.Height) {
 				xform.Position.X = otherXform.Position.X - xform.Width
-				otherBody.Velocity = ecs.VectorFloat32{X: -otherBody.Velocity.X, Y: -otherBody.Velocity.Y}
+				otherBody.Velocity = otherBody.Velocity.Negate()
 				collisions[entity] = other
 			} else if xform.Position.X <= (otherXform.Position.X+otherXform.Width) &&
 				xform.Position.X >= otherXform.Position.X &&
 				(xform.Position.Y+xform.Height) >= otherXform.Position.Y &&
 				(xform.Position.Y) <= (otherXform.Position.Y+otherXform.Height) {
 				xform.Position.X = otherXform.Position.X + otherXform.Width
-				otherBody.Velocity = ecs.VectorFloat32{X: -otherBody.Velocity.X, Y: -otherBody.Velocity.Y}
+				otherBody.Velocity = otherBody.Velocity.Negate()
 				collisions[entity] = other
 			}
-
-			var colour = system.Component(other, ecs.Colour{}.Name()).(*ecs.Colour)
-			colour.Red = 255
-			colour.Green = 255
-			colour.Blue = 255
 		}
 	}
 
+	for _, entity := range entities {
+		var colour = system.Component(entity, ecs.Colour{}.Name()).(*ecs.Colour)
+		colour.Red = 255
+		colour.Green = 255
+		colour.Blue = 255
+	}
+
 	for key, other := range collisions {
 		var colour = system.Component(key, ecs.Colour{}.Name()).(*ecs.Colour)
 		colour.Red = 255
@@ -270,8 +294,8 @@ func (system *physics) Update(dt float32) {
 		// var exitScreenRight bool = (xform.Position.X + xform.Radius) >= float32(windowWidth)
 		// var exitScreenLeft bool = (int(xform.Position.X - xform.Radius)) <= 0
 
-		xform.Position.Add(body.Velocity.Multiply(dt))
-		// body.Velocity.Add(gravity.Force.Multiply(dt)) // drag, friction?
+		xform.Position.Vec3 = xform.Position.Add(body.Velocity.Scale(dt))
+		// body.Velocity = body.Velocity.Add(gravity.Force.Scale(dt)) // drag, friction?
 	}
 }
 
@@ -338,21 +362,9 @@ func (system *controller) Update(dt float32) {
 	for _, entity := range system.Entities() {
 		// var input = system.Component(entity, input{}.Name()).(*input)
 		var xform = system.Component(entity, ecs.Transform{}.Name()).(*ecs.Transform)
+		var actions = system.World().Resource("actions").(*engine.ActionMap)
 
-		if engine.IsKeyPressed(sdl.SCANCODE_UP) {
-			xform.Position.Y -= 500 * dt
-		}
-
-		if engine.IsKeyPressed(sdl.SCANCODE_DOWN) {
-			xform.Position.Y += 500 * dt
-		}
-
-		if engine.IsKeyPressed(sdl.SCANCODE_RIGHT) {
-			xform.Position.X += 500 * dt
-		}
-
-		if engine.IsKeyPressed(sdl.SCANCODE_LEFT) {
-			xform.Position.X -= 500 * dt
-		}
+		xform.Position.X += 500 * dt * actions.Value("move_x")
+		xform.Position.Y += 500 * dt * actions.Value("move_y")
 	}
 }
@@ -1,6 +1,9 @@
 package ecs
 
-import "github.com/willf/bitset"
+import (
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+	"github.com/willf/bitset"
+)
 
 // MaxComponents is the total amount of components that each entity is allowed
 // to have.
@@ -21,12 +24,21 @@ type Component interface {
 	Name() string
 }
 
+// Bundle is a set of components that are attached to an entity together.
+type Bundle []Component
+
 // ComponentManager takes care of creating, deleting, reading, and signing
 // components to entities.
 type ComponentManager interface {
 	// Register will reserve a new ID by the given name for a component.
+	// Registering the same name more than once has no effect, so that many
+	// plugins can share a component.
 	Register(name string)
 
+	// Unregister will remove the component by the given name, along with it's
+	// data for every entity. It's ID is not given to another component.
+	Unregister(name string)
+
 	// Read will return the component data by name for the given entity.
 	Read(entity Entity, name string) Component
 
@@ -67,6 +79,10 @@ func (manager *componentManager) Read(entity Entity, name string) Component {
 }
 
 func (manager *componentManager) Register(name string) {
+	if _, exists := manager.signatures[name]; exists {
+		return
+	}
+
 	manager.signatures[name] = manager.next
 	var components = new(componentEntityMap)
 	components.entityComponents = make(map[int]Component)
@@ -77,7 +93,13 @@ func (manager *componentManager) Register(name string) {
 	manager.next++
 }
 
+func (manager *componentManager) Unregister(name string) {
+	delete(manager.signatures, name)
+	delete(manager.components, name)
+}
+
 func (manager *componentManager) Remove(entity Entity, name string) {
+	manager.components[name].remove(entity)
 }
 
 func (manager *componentManager) Sign(names ...string) *bitset.BitSet {
@@ -102,6 +124,12 @@ type componentEntityMap struct {
 }
 
 func (pack *componentEntityMap) insert(entity Entity, component Component) {
+	if index, exists := pack.entityIndexMap[entity]; exists {
+		pack.entityComponents[index] = component
+
+		return
+	}
+
 	var newIndex = pack.size
 	pack.entityIndexMap[entity] = newIndex
 	pack.indexEntityMap[newIndex] = entity
@@ -110,52 +138,42 @@ func (pack *componentEntityMap) insert(entity Entity, component Component) {
 	pack.size++
 }
 
+// remove keeps the components packed by moving the last component into the
+// slot of the removed one.
 func (pack *componentEntityMap) remove(entity Entity) {
-	delete(pack.entityComponents, pack.entityIndexMap[entity])
-	// pack.entityComponents[pack.entityIndexMap[entity]]
+	var removedIndex, exists = pack.entityIndexMap[entity]
 
-	pack.size--
-}
+	if !exists {
+		return
+	}
 
-func (pack *componentEntityMap) read(entity Entity) Component {
-	return pack.entityComponents[pack.entityIndexMap[entity]]
-}
+	var lastIndex = pack.size - 1
+	var lastEntity = pack.indexEntityMap[lastIndex]
 
-type VectorFloat32 struct {
-	X, Y, Z float32
-}
+	pack.entityComponents[removedIndex] = pack.entityComponents[lastIndex]
+	pack.entityIndexMap[lastEntity] = removedIndex
+	pack.indexEntityMap[removedIndex] = lastEntity
 
-func (left *VectorFloat32) Add(right *VectorFloat32) {
-	left.X += right.X
-	left.Y += right.Y
-	left.Z += right.Z
-}
+	delete(pack.entityComponents, lastIndex)
+	delete(pack.indexEntityMap, lastIndex)
+	delete(pack.entityIndexMap, entity)
 
-func (left *VectorFloat32) Subtract(right *VectorFloat32) {
-	left.X -= right.X
-	left.Y -= right.Y
-	left.Z -= right.Z
+	pack.size--
 }
 
-func (left *VectorFloat32) Multiply(right float32) *VectorFloat32 {
-	return &VectorFloat32{
-		X: left.X * right,
-		Y: left.Y * right,
-		Z: left.Z * right,
-	}
-}
+func (pack *componentEntityMap) read(entity Entity) Component {
+	var index, exists = pack.entityIndexMap[entity]
 
-func (left *VectorFloat32) Divide(right float32) *VectorFloat32 {
-	return &VectorFloat32{
-		X: left.X / right,
-		Y: left.Y / right,
-		Z: left.Z / right,
+	if !exists {
+		return nil
 	}
+
+	return pack.entityComponents[index]
 }
 
 // Acceleration describes an entity's rate at which it increases it's velocity.
 type Acceleration struct {
-	VectorFloat32
+	maths.Vec3
 }
 
 func (Acceleration) Name() string {
@@ -183,12 +201,12 @@ func (Dimensions) Name() string {
 
 // // Force describes the amount of pressure an entity is under.
 // type Force struct {
-// 	VectorFloat32
+// 	maths.Vec3
 // }
 
 // Gravity represents the amount of gravitational force the entity is under.
 type Gravity struct {
-	Force VectorFloat32
+	Force maths.Vec3
 }
 
 func (Gravity) Name() string {
@@ -198,7 +216,7 @@ func (Gravity) Name() string {
 // Position is a representation of the location of a 2D game object in world
 // space.
 type Position struct {
-	VectorFloat32
+	maths.Vec3
 }
 
 func (Position) Name() string {
@@ -211,16 +229,22 @@ func (Position) Name() string {
 // it.
 type RigidBody struct {
 	Acceleration
-	Velocity VectorFloat32
+	Velocity maths.Vec3
 }
 
 func (RigidBody) Name() string {
 	return "rigid_body"
 }
 
-// Rotation describes an entity's angle transformation.
+// Rotation describes an entity's angle transformation as euler angles around
+// each axis, in radians. 2D entities only need to rotate around the z axis.
 type Rotation struct {
-	X, Y, Z int32
+	maths.Vec3
+}
+
+// Quat returns the rotation as a quaternion.
+func (rotation Rotation) Quat() maths.Quat {
+	return maths.QuatEuler(rotation.X, rotation.Y, rotation.Z)
 }
 
 func (Rotation) Name() string {
@@ -229,7 +253,7 @@ func (Rotation) Name() string {
 
 // // Scale describes an entity's dimensional scale.
 // type Scale struct {
-// 	VectorFloat32
+// 	maths.Vec3
 // }
 
 // Transform describes an entity which has a position, rotation, and scale.
@@ -237,7 +261,7 @@ type Transform struct {
 	Position
 	Rotation
 	Dimensions
-	Scale VectorFloat32
+	Scale maths.Vec3
 }
 
 func (Transform) Name() string {
@@ -246,5 +270,5 @@ func (Transform) Name() string {
 
 // // Velocity is a 2D representation of movement for an object in the game world.
 // type Velocity struct {
-// 	VectorFloat32
+// 	maths.Vec3
 // }
@@ -0,0 +1,83 @@
+package ecs
+
+// CreateEventManager will new up an empty manager with no event types
+// registered.
+func CreateEventManager() EventManager {
+	var manager = new(eventManager)
+	manager.queues = make(map[string][]Event)
+
+	return manager
+}
+
+// Event is a message emitted by one system to be read by others during the
+// same frame.
+type Event interface {
+	Name() string
+}
+
+// EventManager takes care of queueing and reading events by name.
+type EventManager interface {
+	// Register will reserve a new queue by the given name for an event type.
+	// Registering the same name more than once has no effect.
+	Register(name string)
+
+	// Unregister will remove the queue by the given name, dropping any events
+	// in it.
+	Unregister(name string)
+
+	// Registered tells the caller if an event type by the given name exists.
+	Registered(name string) bool
+
+	// Emit will append the event to the queue for it's name. Events that were
+	// never registered are dropped, since nothing can be listening for them.
+	Emit(event Event)
+
+	// Read will return all events emitted by the given name since the last
+	// flush, in the order they were emitted.
+	Read(name string) []Event
+
+	// Flush will empty every queue, ready for the next frame.
+	Flush()
+}
+
+type eventManager struct {
+	queues map[string][]Event
+}
+
+func (manager *eventManager) Register(name string) {
+	if manager.Registered(name) {
+		return
+	}
+
+	manager.queues[name] = nil
+}
+
+func (manager *eventManager) Registered(name string) bool {
+	var _, exists = manager.queues[name]
+
+	return exists
+}
+
+func (manager *eventManager) Unregister(name string) {
+	delete(manager.queues, name)
+}
+
+func (manager *eventManager) Emit(event Event) {
+	var name = event.Name()
+
+	if !manager.Registered(name) {
+		return
+	}
+
+	manager.queues[name] = append(manager.queues[name], event)
+}
+
+func (manager *eventManager) Read(name string) []Event {
+	return manager.queues[name]
+}
+
+func (manager *eventManager) Flush() {
+	for name := range manager.queues {
+		manager.queues[name] = nil
+	}
+}
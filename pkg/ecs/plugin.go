@@ -0,0 +1,178 @@
+package ecs
+
+import "fmt"
+
+// Plugin bundles a reusable set of components, systems, resources, and events
+// that can be built into any world with a single call.
+type Plugin interface {
+	// Name returns a unique identifier for the plugin.
+	Name() string
+
+	// Dependencies lists the names of other plugins that must be built into
+	// the world before this one.
+	Dependencies() []string
+
+	// Build registers the plugin's components, systems, resources, and events
+	// with the given world.
+	Build(world World)
+}
+
+func createPluginManager() *pluginManager {
+	var manager = new(pluginManager)
+	manager.built = make(map[string]bool)
+	manager.owners = make(map[string]string)
+
+	return manager
+}
+
+// shared are the kinds of registration that many plugins may make under the
+// same name. Registering a component or event again has no effect, so plugins
+// can share them (such as every plugin that reads a "transform"), whereas a
+// second system or resource would replace the first.
+var shared = map[string]bool{
+	"component": true,
+	"event":     true,
+}
+
+// worldOwner is the owner given to anything registered directly with the world
+// rather than by a plugin.
+const worldOwner = "world"
+
+// registration is a single kind and name claimed while building plugins.
+type registration struct {
+	kind, name string
+}
+
+// pluginManager keeps track of which plugins have been built into a world and
+// which plugin owns each component, system, resource, and event, so that two
+// plugins registering the same system or resource can be reported instead of
+// silently overwriting one another. Everything newly claimed while building is
+// journaled, so that a failed build can be undone.
+type pluginManager struct {
+	built     map[string]bool
+	owners    map[string]string
+	building  string
+	conflicts []error
+	journal   []registration
+}
+
+// order validates the given plugins and sorts them so that every plugin comes
+// after it's dependencies, otherwise keeping the order they were given in.
+func (manager *pluginManager) order(plugins []Plugin) ([]Plugin, error) {
+	var pending = make(map[string]Plugin, len(plugins))
+
+	for _, plugin := range plugins {
+		var name = plugin.Name()
+
+		if _, duplicate := pending[name]; duplicate || manager.built[name] {
+			return nil, fmt.Errorf("ecs: plugin %q is already registered", name)
+		}
+
+		pending[name] = plugin
+	}
+
+	for _, plugin := range plugins {
+		for _, dependency := range plugin.Dependencies() {
+			if _, exists := pending[dependency]; !exists && !manager.built[dependency] {
+				return nil, fmt.Errorf("ecs: plugin %q depends on missing plugin %q", plugin.Name(), dependency)
+			}
+		}
+	}
+
+	var sorted = make([]Plugin, 0, len(plugins))
+	var visiting = make(map[string]bool)
+	var visited = make(map[string]bool)
+	var visit func(plugin Plugin) error
+
+	visit = func(plugin Plugin) error {
+		var name = plugin.Name()
+
+		if visited[name] {
+			return nil
+		}
+
+		if visiting[name] {
+			return fmt.Errorf("ecs: plugin %q has a circular dependency", name)
+		}
+
+		visiting[name] = true
+
+		for _, dependency := range plugin.Dependencies() {
+			if next, exists := pending[dependency]; exists {
+				if err := visit(next); err != nil {
+					return err
+				}
+			}
+		}
+
+		visiting[name] = false
+		visited[name] = true
+		sorted = append(sorted, plugin)
+
+		return nil
+	}
+
+	for _, plugin := range plugins {
+		if err := visit(plugin); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+// claim records the plugin currently being built as the owner of the given
+// kind and name, telling if it may be registered. A system or resource that
+// another plugin or the world already owns is noted as a conflict and must not
+// be registered, while shared kinds keep their first owner and are always
+// allowed. Registrations made directly with the world are owned by the world
+// and always allowed, as there is nobody to report a conflict to.
+func (manager *pluginManager) claim(kind, name string) bool {
+	var key = kind + ":" + name
+	var owner, exists = manager.owners[key]
+
+	if "" == manager.building {
+		if !exists {
+			manager.owners[key] = worldOwner
+		}
+
+		return true
+	}
+
+	if exists && shared[kind] {
+		return true
+	}
+
+	if exists && owner != manager.building {
+		manager.conflicts = append(manager.conflicts, fmt.Errorf(
+			"ecs: %s %q registered by plugin %q is already registered by %s",
+			kind,
+			name,
+			manager.building,
+			describe(owner),
+		))
+
+		return false
+	}
+
+	if !exists {
+		manager.owners[key] = manager.building
+		manager.journal = append(manager.journal, registration{kind, name})
+	}
+
+	return true
+}
+
+// release forgets the owner of the given kind and name.
+func (manager *pluginManager) release(kind, name string) {
+	delete(manager.owners, kind+":"+name)
+}
+
+// describe names the owner of a registration for an error message.
+func describe(owner string) string {
+	if worldOwner == owner {
+		return "the world"
+	}
+
+	return fmt.Sprintf("plugin %q", owner)
+}
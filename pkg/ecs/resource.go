@@ -0,0 +1,53 @@
+package ecs
+
+// CreateResourceManager will new up an empty manager with no resources stored.
+func CreateResourceManager() ResourceManager {
+	var manager = new(resourceManager)
+	manager.resources = make(map[string]Resource)
+
+	return manager
+}
+
+// Resource is a globally unique piece of data that lives in the world without
+// being attached to any entity (e.g., input state, asset caches, settings).
+type Resource interface {
+	Name() string
+}
+
+// ResourceManager takes care of storing and reading world resources by name.
+type ResourceManager interface {
+	// Insert will store the given resource, replacing any existing resource
+	// with the same name.
+	Insert(resource Resource)
+
+	// Read will return the resource by name, or nil if it does not exist.
+	Read(name string) Resource
+
+	// Remove will delete the resource by name.
+	Remove(name string)
+
+	// Has tells the caller if a resource by the given name is stored.
+	Has(name string) bool
+}
+
+type resourceManager struct {
+	resources map[string]Resource
+}
+
+func (manager *resourceManager) Insert(resource Resource) {
+	manager.resources[resource.Name()] = resource
+}
+
+func (manager *resourceManager) Read(name string) Resource {
+	return manager.resources[name]
+}
+
+func (manager *resourceManager) Remove(name string) {
+	delete(manager.resources, name)
+}
+
+func (manager *resourceManager) Has(name string) bool {
+	var _, exists = manager.resources[name]
+
+	return exists
+}
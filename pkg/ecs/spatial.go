@@ -0,0 +1,423 @@
+package ecs
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+)
+
+// CreateSpatialIndex will new up an empty spatial index that divides the world
+// into square cells of the given size. Cells roughly the size of the average
+// entity give the best results.
+func CreateSpatialIndex(cellSize float32) *SpatialIndex {
+	var index = new(SpatialIndex)
+	index.size = cellSize
+	index.cells = make(map[cell][]Entity)
+	index.bounds = make(map[Entity]maths.Rect)
+
+	return index
+}
+
+// SpatialIndex is a uniform grid over the Transform of every entity, letting
+// systems find entities by area instead of looping over all of them.
+//
+// The index is a plugin, registering itself as both a system and a resource.
+// Queries reflect the positions from it's last Update, which is not done when
+// a Transform changes. The engine updates the index before every fixed step
+// and again before the frame's update closure; anywhere else, call Update
+// after moving entities.
+type SpatialIndex struct {
+	SystemAccess
+	size   float32
+	cells  map[cell][]Entity
+	bounds map[Entity]maths.Rect
+
+	// min and max are the corners of the occupied cells, which may be larger
+	// than needed until they are measured again when stale.
+	min, max cell
+	stale    bool
+}
+
+type cell struct {
+	x, y int32
+}
+
+// squaredDistance is the squared distance from the point to the nearest edge of the
+// bounds, or zero if the point is inside of them.
+func squaredDistance(bounds maths.Rect, point maths.Vec2) float32 {
+	return point.Subtract(bounds.Closest(point)).LengthSquared()
+}
+
+func (SpatialIndex) Name() string {
+	return "spatial_index"
+}
+
+func (SpatialIndex) Dependencies() []string {
+	return nil
+}
+
+// Build will register the index as a system of all entities with a Transform
+// and store it as a resource for other systems to query.
+func (index *SpatialIndex) Build(world World) {
+	var transform = Transform{}.Name()
+
+	world.RegisterComponent(transform)
+	world.RegisterSystem(index, transform)
+	world.AddResource(index)
+}
+
+// Update will move every entity whose Transform has changed since the last
+// update into the cells it now occupies.
+func (index *SpatialIndex) Update(dt float32) {
+	for _, entity := range index.Entities() {
+		var xform = index.Component(entity, Transform{}.Name()).(*Transform)
+		var bounds = boundsOf(xform)
+
+		if previous, exists := index.bounds[entity]; exists {
+			if previous == bounds {
+				continue
+			}
+
+			index.remove(entity, previous)
+		}
+
+		index.insert(entity, bounds)
+	}
+}
+
+// Unsubscribe will drop the entity from the index along with the system.
+func (index *SpatialIndex) Unsubscribe(entity Entity) {
+	index.SystemAccess.Unsubscribe(entity)
+
+	if bounds, exists := index.bounds[entity]; exists {
+		index.remove(entity, bounds)
+	}
+}
+
+// Rect returns all entities overlapping the given rectangle, in ascending
+// order of their ID.
+func (index *SpatialIndex) Rect(x, y, width, height float32) []Entity {
+	var area = maths.Rect{X: x, Y: y, Width: width, Height: height}
+
+	return index.query(area, area.Intersects)
+}
+
+// Radius returns all entities overlapping the circle at the given centre, in
+// ascending order of their ID.
+func (index *SpatialIndex) Radius(x, y, radius float32) []Entity {
+	var circle = maths.Circle{Centre: maths.Vec2{X: x, Y: y}, Radius: radius}
+
+	return index.query(circle.Bounds(), func(bounds maths.Rect) bool {
+		return bounds.IntersectsCircle(circle)
+	})
+}
+
+// Nearest returns the entity closest to the given point, skipping any of the
+// excluded entities. False is returned if there are no other entities.
+//
+// Rings of cells are searched outwards from the point, stopping once nothing
+// beyond them could be closer, once they pass the occupied cells, or once
+// every entity has been seen. Should the rings cover more cells than there are
+// entities, the entities not yet seen are checked directly instead.
+func (index *SpatialIndex) Nearest(x, y float32, exclude ...Entity) (Entity, bool) {
+	if 0 == len(index.bounds) {
+		return 0, false
+	}
+
+	var skip = make(map[Entity]bool, len(exclude))
+
+	for _, entity := range exclude {
+		skip[entity] = true
+	}
+
+	var point = maths.Vec2{X: x, Y: y}
+	var origin = index.cell(x, y)
+	var seen = make(map[Entity]bool)
+	var nearest Entity
+	var best = float32(math.Inf(1))
+	var found = false
+
+	var consider = func(entity Entity) {
+		seen[entity] = true
+
+		if skip[entity] {
+			return
+		}
+
+		var distance = squaredDistance(index.bounds[entity], point)
+
+		if distance < best || (distance == best && entity < nearest) {
+			nearest = entity
+			best = distance
+			found = true
+		}
+	}
+
+	var limit = index.reach(origin)
+	var walked int
+
+	for ring := int32(0); ring <= limit && len(seen) < len(index.bounds); ring++ {
+		// every entity in this ring or beyond is at least this far away
+		var reach = float32(ring-1) * index.size
+
+		if found && ring > 0 && (reach*reach) > best {
+			break
+		}
+
+		if walked += perimeterLength(ring); walked > len(index.bounds) {
+			for entity := range index.bounds {
+				if !seen[entity] {
+					consider(entity)
+				}
+			}
+
+			break
+		}
+
+		perimeter(origin, ring, func(key cell) {
+			for _, entity := range index.cells[key] {
+				if !seen[entity] {
+					consider(entity)
+				}
+			}
+		})
+	}
+
+	return nearest, found
+}
+
+// reach is the number of rings from the origin to the furthest corner of the
+// occupied cells.
+func (index *SpatialIndex) reach(origin cell) int32 {
+	if index.stale {
+		index.measure()
+	}
+
+	var furthest int32
+
+	for _, corner := range []cell{index.min, index.max, {index.min.x, index.max.y}, {index.max.x, index.min.y}} {
+		if distance := chebyshev(origin, corner); distance > furthest {
+			furthest = distance
+		}
+	}
+
+	return furthest
+}
+
+// measure will shrink the corners of the occupied cells to fit them again.
+func (index *SpatialIndex) measure() {
+	var first = true
+
+	for key := range index.cells {
+		if first {
+			index.min, index.max, first = key, key, false
+
+			continue
+		}
+
+		index.extend(key, key)
+	}
+
+	index.stale = false
+}
+
+// extend will grow the corners of the occupied cells to cover the given ones.
+func (index *SpatialIndex) extend(min, max cell) {
+	if min.x < index.min.x {
+		index.min.x = min.x
+	}
+
+	if min.y < index.min.y {
+		index.min.y = min.y
+	}
+
+	if max.x > index.max.x {
+		index.max.x = max.x
+	}
+
+	if max.y > index.max.y {
+		index.max.y = max.y
+	}
+}
+
+// query returns the entities in the cells the area covers that match. Only the
+// occupied cells are visited, so a large area costs no more than the index.
+func (index *SpatialIndex) query(area maths.Rect, matches func(bounds maths.Rect) bool) []Entity {
+	if 0 == len(index.cells) {
+		return nil
+	}
+
+	if index.stale {
+		index.measure()
+	}
+
+	var seen = make(map[Entity]bool)
+	var results []Entity
+	var min = index.cell(area.X, area.Y)
+	var max = index.cell(area.X+area.Width, area.Y+area.Height)
+
+	if min.x < index.min.x {
+		min.x = index.min.x
+	}
+
+	if min.y < index.min.y {
+		min.y = index.min.y
+	}
+
+	if max.x > index.max.x {
+		max.x = index.max.x
+	}
+
+	if max.y > index.max.y {
+		max.y = index.max.y
+	}
+
+	for cy := min.y; cy <= max.y; cy++ {
+		for cx := min.x; cx <= max.x; cx++ {
+			for _, entity := range index.cells[cell{cx, cy}] {
+				if seen[entity] {
+					continue
+				}
+
+				seen[entity] = true
+
+				if matches(index.bounds[entity]) {
+					results = append(results, entity)
+				}
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+
+	return results
+}
+
+func (index *SpatialIndex) cell(x, y float32) cell {
+	return cell{
+		int32(math.Floor(float64(x / index.size))),
+		int32(math.Floor(float64(y / index.size))),
+	}
+}
+
+func (index *SpatialIndex) insert(entity Entity, bounds maths.Rect) {
+	var min = index.cell(bounds.X, bounds.Y)
+	var max = index.cell(bounds.X+bounds.Width, bounds.Y+bounds.Height)
+
+	if 0 == len(index.cells) {
+		index.min, index.max, index.stale = min, max, false
+	} else {
+		index.extend(min, max)
+	}
+
+	for cy := min.y; cy <= max.y; cy++ {
+		for cx := min.x; cx <= max.x; cx++ {
+			var key = cell{cx, cy}
+
+			index.cells[key] = append(index.cells[key], entity)
+		}
+	}
+
+	index.bounds[entity] = bounds
+}
+
+func (index *SpatialIndex) remove(entity Entity, bounds maths.Rect) {
+	var min = index.cell(bounds.X, bounds.Y)
+	var max = index.cell(bounds.X+bounds.Width, bounds.Y+bounds.Height)
+
+	for cy := min.y; cy <= max.y; cy++ {
+		for cx := min.x; cx <= max.x; cx++ {
+			var key = cell{cx, cy}
+			var entities = index.cells[key]
+
+			for i, occupant := range entities {
+				if occupant == entity {
+					entities = append(entities[:i], entities[i+1:]...)
+
+					break
+				}
+			}
+
+			if 0 == len(entities) {
+				delete(index.cells, key)
+
+				// the corners only need measuring again when an edge empties
+				if key.x == index.min.x || key.x == index.max.x || key.y == index.min.y || key.y == index.max.y {
+					index.stale = true
+				}
+			} else {
+				index.cells[key] = entities
+			}
+		}
+	}
+
+	delete(index.bounds, entity)
+}
+
+// boundsOf describes the area taken up by a transform. Transforms with a
+// radius are circles centred on their position, while all others are
+// rectangles starting from their top left corner.
+func boundsOf(xform *Transform) maths.Rect {
+	if xform.Radius > 0 {
+		return maths.Circle{Centre: xform.Position.Vec2(), Radius: xform.Radius}.Bounds()
+	}
+
+	return maths.Rect{
+		X:      xform.Position.X,
+		Y:      xform.Position.Y,
+		Width:  xform.Width,
+		Height: xform.Height,
+	}
+}
+
+// perimeter visits the cells that are exactly the given number of rings away
+// from the origin.
+func perimeter(origin cell, ring int32, visit func(key cell)) {
+	if 0 == ring {
+		visit(origin)
+
+		return
+	}
+
+	for x := origin.x - ring; x <= origin.x+ring; x++ {
+		visit(cell{x, origin.y - ring})
+		visit(cell{x, origin.y + ring})
+	}
+
+	for y := origin.y - ring + 1; y < origin.y+ring; y++ {
+		visit(cell{origin.x - ring, y})
+		visit(cell{origin.x + ring, y})
+	}
+}
+
+// perimeterLength is the number of cells in the given ring.
+func perimeterLength(ring int32) int {
+	if 0 == ring {
+		return 1
+	}
+
+	return int(ring) * 8
+}
+
+// chebyshev is the number of rings of cells between two cells.
+func chebyshev(a, b cell) int32 {
+	var dx = a.x - b.x
+	var dy = a.y - b.y
+
+	if dx < 0 {
+		dx = -dx
+	}
+
+	if dy < 0 {
+		dy = -dy
+	}
+
+	if dx > dy {
+		return dx
+	}
+
+	return dy
+}
@@ -1,6 +1,8 @@
 package ecs
 
 import (
+	"sort"
+
 	"github.com/willf/bitset"
 )
 
@@ -14,27 +16,110 @@ type System interface {
 	Name() string
 }
 
+// SystemAccess is embedded into user defined systems to provide them with
+// their subscribed entities and access to the world.
+//
+// Entities are iterated in ascending order of their ID by default, which keeps
+// iteration deterministic no matter the order entities were subscribed in. A
+// system may instead request an order of it's own with SortBy.
 type SystemAccess struct {
-	entities []Entity
-	world    World
+	entities   []Entity
+	subscribed map[Entity]bool
+	less       func(left, right Entity) bool
+	world      World
 }
 
 func (system *SystemAccess) Subscribed(entity Entity) bool {
-	for _, subscription := range system.entities {
+	return system.subscribed[entity]
+}
+
+func (system *SystemAccess) Subscribe(entity Entity) {
+	if nil == system.subscribed {
+		system.subscribed = make(map[Entity]bool)
+	}
+
+	if system.subscribed[entity] {
+		return
+	}
+
+	system.subscribed[entity] = true
+
+	if nil != system.less {
+		// the next call to Entities will move it into place
+		system.entities = append(system.entities, entity)
+
+		return
+	}
+
+	var index = sort.Search(len(system.entities), func(i int) bool {
+		return system.entities[i] >= entity
+	})
+
+	system.entities = append(system.entities, 0)
+	copy(system.entities[index+1:], system.entities[index:])
+	system.entities[index] = entity
+}
+
+func (system *SystemAccess) Unsubscribe(entity Entity) {
+	if !system.subscribed[entity] {
+		return
+	}
+
+	delete(system.subscribed, entity)
+
+	for index, subscription := range system.entities {
 		if entity == subscription {
-			return true
+			system.entities = append(system.entities[:index], system.entities[index+1:]...)
+
+			break
 		}
 	}
+}
 
-	return false
+// SortBy will order the system's entities using the given comparison, which
+// reports whether the left entity should come before the right. Entities that
+// compare equal fall back to ascending order of their ID. Passing nil restores
+// the default order.
+//
+// The order is maintained incrementally every time the entities are read, so
+// keys that change a little between frames (e.g., a y-coordinate) only cost a
+// few swaps to keep sorted.
+func (system *SystemAccess) SortBy(less func(left, right Entity) bool) {
+	system.less = less
+
+	if nil == less {
+		sort.Slice(system.entities, func(i, j int) bool {
+			return system.entities[i] < system.entities[j]
+		})
+
+		return
+	}
+
+	system.sort()
 }
 
-func (system *SystemAccess) Subscribe(entity Entity) {
-	system.entities = append(system.entities, entity)
+// sort is an insertion sort, which is stable and runs in linear time over
+// entities that are already mostly in order from the previous frame.
+func (system *SystemAccess) sort() {
+	var entities = system.entities
+
+	for i := 1; i < len(entities); i++ {
+		for j := i; j > 0 && system.before(entities[j], entities[j-1]); j-- {
+			entities[j], entities[j-1] = entities[j-1], entities[j]
+		}
+	}
 }
 
-func (system *SystemAccess) Unsubscribe(entity Entity) {
-	// TODO
+func (system *SystemAccess) before(left, right Entity) bool {
+	if system.less(left, right) {
+		return true
+	}
+
+	if system.less(right, left) {
+		return false
+	}
+
+	return left < right
 }
 
 func (system *SystemAccess) Updates(world World) {
@@ -45,7 +130,12 @@ func (system *SystemAccess) Component(entity Entity, name string) Component {
 	return system.world.Component(entity, name)
 }
 
+// Entities returns the system's subscribed entities in iteration order.
 func (system *SystemAccess) Entities() []Entity {
+	if nil != system.less {
+		system.sort()
+	}
+
 	return system.entities
 }
 
@@ -63,6 +153,7 @@ func CreateSystemManager() SystemManager {
 
 type SystemManager interface {
 	Register(name string, system System)
+	Remove(name string)
 	Read(name string) System
 	Destroy(entity Entity)
 	Change(entity Entity, signature *bitset.BitSet)
@@ -78,12 +169,21 @@ func (manager *systemManager) Register(name string, system System) {
 	manager.systems[name] = system
 }
 
+func (manager *systemManager) Remove(name string) {
+	delete(manager.systems, name)
+	delete(manager.signatures, name)
+}
+
 func (manager *systemManager) Read(name string) System {
 	return manager.systems[name]
 }
 
 func (manager *systemManager) Destroy(entity Entity) {
-	// TODO
+	for _, system := range manager.systems {
+		if system.Subscribed(entity) {
+			system.Unsubscribe(entity)
+		}
+	}
 }
 
 func (manager *systemManager) Use(name string, signature *bitset.BitSet) {
@@ -93,13 +193,18 @@ func (manager *systemManager) Use(name string, signature *bitset.BitSet) {
 func (manager *systemManager) Change(entity Entity, signature *bitset.BitSet) {
 	for name, system := range manager.systems {
 		var systemSignature = manager.signatures[name]
+		var subscribed = system.Subscribed(entity)
 
-		if systemSignature != nil && !system.Subscribed(entity) && signature.IsSuperSet(systemSignature) {
-			system.Subscribe(entity)
+		if systemSignature != nil && signature.IsSuperSet(systemSignature) {
+			if !subscribed {
+				system.Subscribe(entity)
+			}
 
 			continue
 		}
 
-		system.Unsubscribe(entity)
+		if subscribed {
+			system.Unsubscribe(entity)
+		}
 	}
 }
@@ -10,6 +10,9 @@ func CreateWorld() World {
 	world.components = CreateComponentManager()
 	world.entities = CreateEntityManager()
 	world.systems = CreateSystemManager()
+	world.resources = CreateResourceManager()
+	world.events = CreateEventManager()
+	world.plugins = createPluginManager()
 
 	return world
 }
@@ -50,12 +53,56 @@ type World interface {
 
 	// AttachComponent will assign the given component name and data to the entity.
 	AttachComponent(entity Entity, component Component)
+
+	// AttachBundle will assign every component in the bundle to the entity as
+	// a single change, subscribing the entity to systems only once.
+	AttachBundle(entity Entity, bundle Bundle)
+
+	// AddResource will store the given resource in the world, replacing any
+	// existing resource with the same name.
+	AddResource(resource Resource)
+
+	// Resource will return a resource interface for the given name. The caller
+	// will have to use type assertions to extract the real value from the
+	// result.
+	Resource(name string) Resource
+
+	// RemoveResource will delete the resource with the given name.
+	RemoveResource(name string)
+
+	// RegisterEvent will reserve a new event queue with the given name.
+	RegisterEvent(name string)
+
+	// Emit will queue the given event to be read by other systems.
+	Emit(event Event)
+
+	// Events will return all events with the given name emitted since the last
+	// flush.
+	Events(name string) []Event
+
+	// Flush will discard all queued events. This is done by the engine at the
+	// end of every frame.
+	Flush()
+
+	// AddPlugins will build the given plugins into the world, ordered by their
+	// dependencies. An error is returned if a plugin is registered twice, has a
+	// missing or circular dependency, or registers a system or resource that
+	// another plugin or the world itself already registered. On an error every
+	// component, system, resource, and event registered by the given plugins is
+	// removed again, and none of them are counted as built, so they may be
+	// added once the conflict is fixed. Anything else their builds did, such as
+	// creating entities, is not undone. Components and events may be
+	// registered by any number of plugins.
+	AddPlugins(plugins ...Plugin) error
 }
 
 type world struct {
 	components ComponentManager
 	entities   EntityManager
 	systems    SystemManager
+	resources  ResourceManager
+	events     EventManager
+	plugins    *pluginManager
 }
 
 func (world *world) Component(entity Entity, name string) Component {
@@ -81,12 +128,17 @@ func (world *world) CreateEntity() Entity {
 }
 
 func (world *world) RegisterComponent(name string) {
+	world.plugins.claim("component", name)
 	world.components.Register(name)
 }
 
 func (world *world) RegisterSystem(system System, components ...string) {
 	var name = system.Name()
 
+	if !world.plugins.claim("system", name) {
+		return
+	}
+
 	world.systems.Register(name, system)
 	system.Updates(world)
 
@@ -100,9 +152,13 @@ func (world *world) SignEntity(entity Entity, components ...string) {
 }
 
 func (world *world) AttachComponent(entity Entity, component Component) {
-	var name = component.Name()
+	world.AttachBundle(entity, Bundle{component})
+}
 
-	world.components.Attach(entity, name, component)
+func (world *world) AttachBundle(entity Entity, bundle Bundle) {
+	if 0 == len(bundle) {
+		return
+	}
 
 	var signature = world.Entity(entity)
 
@@ -110,7 +166,13 @@ func (world *world) AttachComponent(entity Entity, component Component) {
 		signature = new(bitset.BitSet)
 	}
 
-	signature.Set(uint(world.components.Signature(name)))
+	for _, component := range bundle {
+		var name = component.Name()
+
+		world.components.Attach(entity, name, component)
+		signature.Set(uint(world.components.Signature(name)))
+	}
+
 	world.entities.Sign(entity, signature)
 	world.systems.Change(entity, signature)
 }
@@ -122,3 +184,98 @@ func (world *world) System(name string) System {
 func (world *world) Update(name string, dt float32) {
 	world.System(name).Update(dt)
 }
+
+func (world *world) AddResource(resource Resource) {
+	if !world.plugins.claim("resource", resource.Name()) {
+		return
+	}
+
+	world.resources.Insert(resource)
+}
+
+func (world *world) Resource(name string) Resource {
+	return world.resources.Read(name)
+}
+
+func (world *world) RemoveResource(name string) {
+	world.resources.Remove(name)
+}
+
+func (world *world) RegisterEvent(name string) {
+	world.plugins.claim("event", name)
+	world.events.Register(name)
+}
+
+func (world *world) Emit(event Event) {
+	world.events.Emit(event)
+}
+
+func (world *world) Events(name string) []Event {
+	return world.events.Read(name)
+}
+
+func (world *world) Flush() {
+	world.events.Flush()
+}
+
+func (world *world) AddPlugins(plugins ...Plugin) error {
+	var sorted, err = world.plugins.order(plugins)
+
+	if err != nil {
+		return err
+	}
+
+	world.plugins.journal = nil
+
+	for index, plugin := range sorted {
+		world.plugins.building = plugin.Name()
+
+		plugin.Build(world)
+
+		world.plugins.building = ""
+
+		if len(world.plugins.conflicts) > 0 {
+			err = world.plugins.conflicts[0]
+			world.plugins.conflicts = nil
+
+			world.undo(sorted[:index])
+
+			return err
+		}
+
+		world.plugins.built[plugin.Name()] = true
+	}
+
+	world.plugins.journal = nil
+
+	return nil
+}
+
+// undo removes everything registered while building the given plugins, newest
+// first, and forgets that they were built.
+func (world *world) undo(plugins []Plugin) {
+	var journal = world.plugins.journal
+
+	for index := len(journal) - 1; index >= 0; index-- {
+		var entry = journal[index]
+
+		switch entry.kind {
+		case "component":
+			world.components.Unregister(entry.name)
+		case "system":
+			world.systems.Remove(entry.name)
+		case "resource":
+			world.resources.Remove(entry.name)
+		case "event":
+			world.events.Unregister(entry.name)
+		}
+
+		world.plugins.release(entry.kind, entry.name)
+	}
+
+	for _, plugin := range plugins {
+		delete(world.plugins.built, plugin.Name())
+	}
+
+	world.plugins.journal = nil
+}
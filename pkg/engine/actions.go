@@ -0,0 +1,487 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+)
+
+// ActionThreshold is how far an analog input must be pushed before an action
+// bound to it counts as pressed.
+const ActionThreshold float32 = 0.5
+
+// Device is the kind of input that a binding reads from.
+type Device int
+
+// Devices that actions can be bound to.
+const (
+	DeviceKeyboard Device = iota
+	DeviceMouse
+	DeviceGamepadButton
+	DeviceGamepadAxis
+)
+
+var devicePrefixes = map[Device]string{
+	DeviceKeyboard:      "key",
+	DeviceMouse:         "mouse",
+	DeviceGamepadButton: "button",
+	DeviceGamepadAxis:   "axis",
+}
+
+// Binding is a single input that drives an action, such as a key or a gamepad
+// axis. The input's value, which is one while a key or button is held, is
+// multiplied by the binding's scale, so that opposite keys can drive the same
+// axis.
+type Binding struct {
+	Device Device
+	Code   int
+	Scale  float32
+}
+
+// BindKey creates a binding to the key.
+func BindKey(key Key) Binding {
+	return Binding{DeviceKeyboard, int(key), 1}
+}
+
+// BindMouse creates a binding to the mouse button.
+func BindMouse(button MouseButton) Binding {
+	return Binding{DeviceMouse, int(button), 1}
+}
+
+// BindGamepadButton creates a binding to the button on any gamepad.
+func BindGamepadButton(button GamepadButton) Binding {
+	return Binding{DeviceGamepadButton, int(button), 1}
+}
+
+// BindGamepadAxis creates a binding to the axis on any gamepad.
+func BindGamepadAxis(axis GamepadAxis) Binding {
+	return Binding{DeviceGamepadAxis, int(axis), 1}
+}
+
+// Scaled returns a copy of the binding with the given scale, such as -1 to
+// invert it.
+func (binding Binding) Scaled(scale float32) Binding {
+	binding.Scale = scale
+
+	return binding
+}
+
+// ParseBinding creates a binding from it's name, such as "key:Space",
+// "mouse:Left", "button:A", or "axis:LeftY". Inputs without a name can be
+// given by their number, such as "key:100".
+func ParseBinding(text string) (Binding, error) {
+	var parts = strings.SplitN(text, ":", 2)
+
+	if 2 != len(parts) {
+		return Binding{}, fmt.Errorf("invalid binding %q", text)
+	}
+
+	for device, prefix := range devicePrefixes {
+		if prefix != parts[0] {
+			continue
+		}
+
+		if code, ok := device.lookup(parts[1]); ok {
+			return Binding{device, code, 1}, nil
+		}
+
+		if code, err := strconv.Atoi(parts[1]); nil == err {
+			return Binding{device, code, 1}, nil
+		}
+
+		return Binding{}, fmt.Errorf("unknown input %q in binding %q", parts[1], text)
+	}
+
+	return Binding{}, fmt.Errorf("unknown device %q in binding %q", parts[0], text)
+}
+
+// String returns the binding's name, as understood by ParseBinding.
+func (binding Binding) String() string {
+	var name string
+	var ok bool
+
+	switch binding.Device {
+	case DeviceKeyboard:
+		name, ok = keyNames[Key(binding.Code)]
+	case DeviceMouse:
+		name, ok = mouseButtonNames[MouseButton(binding.Code)]
+	case DeviceGamepadButton:
+		name, ok = gamepadButtonNames[GamepadButton(binding.Code)]
+	case DeviceGamepadAxis:
+		name, ok = gamepadAxisNames[GamepadAxis(binding.Code)]
+	}
+
+	if !ok {
+		name = strconv.Itoa(binding.Code)
+	}
+
+	return devicePrefixes[binding.Device] + ":" + name
+}
+
+// bindingJSON is how a binding with a scale other than one is saved.
+type bindingJSON struct {
+	Input string  `json:"input"`
+	Scale float32 `json:"scale"`
+}
+
+// MarshalJSON saves the binding as it's name, or as an object with the name
+// and scale when the scale is not one.
+func (binding Binding) MarshalJSON() ([]byte, error) {
+	if 1 == binding.Scale {
+		return json.Marshal(binding.String())
+	}
+
+	return json.Marshal(bindingJSON{binding.String(), binding.Scale})
+}
+
+// UnmarshalJSON loads a binding saved by MarshalJSON.
+func (binding *Binding) UnmarshalJSON(data []byte) error {
+	var saved = bindingJSON{Scale: 1}
+
+	if err := json.Unmarshal(data, &saved.Input); err != nil {
+		if err = json.Unmarshal(data, &saved); err != nil {
+			return err
+		}
+	}
+
+	var parsed, err = ParseBinding(saved.Input)
+
+	if err != nil {
+		return err
+	}
+
+	*binding = parsed.Scaled(saved.Scale)
+
+	return nil
+}
+
+// value reads the binding's scaled value from the input state.
+func (binding Binding) value(input *InputState) float32 {
+	var value float32
+
+	switch binding.Device {
+	case DeviceKeyboard:
+		if input.Held(Key(binding.Code)) {
+			value = 1
+		}
+	case DeviceMouse:
+		if input.ButtonHeld(MouseButton(binding.Code)) {
+			value = 1
+		}
+	case DeviceGamepadButton:
+		for _, gamepad := range input.gamepads {
+			if gamepad.Held(GamepadButton(binding.Code)) {
+				value = 1
+			}
+		}
+	case DeviceGamepadAxis:
+		for _, gamepad := range input.gamepads {
+			var axis = gamepad.Axis(GamepadAxis(binding.Code))
+
+			if maths.Abs(axis) > maths.Abs(value) {
+				value = axis
+			}
+		}
+	}
+
+	return value * binding.Scale
+}
+
+// analog tells if the binding reads from an axis, rather than something that
+// is either up or down.
+func (binding Binding) analog() bool {
+	return DeviceGamepadAxis == binding.Device
+}
+
+// edge tells if the binding's key or button went down, or came up, since the
+// previous frame, even if it did both. Axes have no edges of their own.
+func (binding Binding) edge(input *InputState, pressed bool) bool {
+	switch binding.Device {
+	case DeviceKeyboard:
+		if pressed {
+			return input.JustPressed(Key(binding.Code))
+		}
+
+		return input.JustReleased(Key(binding.Code))
+	case DeviceMouse:
+		if pressed {
+			return input.ButtonJustPressed(MouseButton(binding.Code))
+		}
+
+		return input.ButtonJustReleased(MouseButton(binding.Code))
+	case DeviceGamepadButton:
+		for _, gamepad := range input.gamepads {
+			if pressed && gamepad.JustPressed(GamepadButton(binding.Code)) {
+				return true
+			}
+
+			if !pressed && gamepad.JustReleased(GamepadButton(binding.Code)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// lookup finds the code of the device's input with the given name.
+func (device Device) lookup(name string) (int, bool) {
+	switch device {
+	case DeviceKeyboard:
+		for key, known := range keyNames {
+			if known == name {
+				return int(key), true
+			}
+		}
+	case DeviceMouse:
+		for button, known := range mouseButtonNames {
+			if known == name {
+				return int(button), true
+			}
+		}
+	case DeviceGamepadButton:
+		for button, known := range gamepadButtonNames {
+			if known == name {
+				return int(button), true
+			}
+		}
+	case DeviceGamepadAxis:
+		for axis, known := range gamepadAxisNames {
+			if known == name {
+				return int(axis), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// ActionMap gives names to the things a player can do, such as "fire" or
+// "move_y", and binds each of them to any number of keys, mouse buttons, and
+// gamepad inputs. Systems ask for actions by name instead of checking keys
+// themselves, so controls can be rebound while the program runs or loaded
+// from a config file.
+//
+// It is added to every world as a resource named "actions".
+type ActionMap struct {
+	input    *InputState
+	bindings map[string][]Binding
+	previous map[string]bool
+}
+
+// CreateActionMap returns an action map with no bindings that reads from the
+// given input state.
+func CreateActionMap(input *InputState) *ActionMap {
+	return &ActionMap{
+		input:    input,
+		bindings: make(map[string][]Binding),
+		previous: make(map[string]bool),
+	}
+}
+
+// Name of the actions resource.
+func (actions *ActionMap) Name() string {
+	return "actions"
+}
+
+// Bind will add the given bindings to the action, ignoring any it already has.
+func (actions *ActionMap) Bind(action string, bindings ...Binding) {
+	for _, binding := range bindings {
+		if !actions.Bound(action, binding) {
+			actions.bindings[action] = append(actions.bindings[action], binding)
+		}
+	}
+}
+
+// Bound tells if the action has the given binding.
+func (actions *ActionMap) Bound(action string, binding Binding) bool {
+	for _, existing := range actions.bindings[action] {
+		if existing == binding {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unbind will remove the given binding from the action.
+func (actions *ActionMap) Unbind(action string, binding Binding) {
+	var bindings = actions.bindings[action]
+
+	for i, existing := range bindings {
+		if existing == binding {
+			actions.bindings[action] = append(bindings[:i:i], bindings[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Rebind will replace all of the action's bindings with the given ones.
+func (actions *ActionMap) Rebind(action string, bindings ...Binding) {
+	actions.bindings[action] = nil
+
+	actions.Bind(action, bindings...)
+}
+
+// Clear will remove the action and all of it's bindings.
+func (actions *ActionMap) Clear(action string) {
+	delete(actions.bindings, action)
+	delete(actions.previous, action)
+}
+
+// Bindings returns the action's bindings.
+func (actions *ActionMap) Bindings(action string) []Binding {
+	return append([]Binding(nil), actions.bindings[action]...)
+}
+
+// Actions returns the name of every action, in alphabetical order.
+func (actions *ActionMap) Actions() []string {
+	var names = make([]string, 0, len(actions.bindings))
+
+	for action := range actions.bindings {
+		names = append(names, action)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Value returns the sum of the action's bindings, from -1 to 1, for actions
+// used as an axis.
+func (actions *ActionMap) Value(action string) float32 {
+	var total float32
+
+	for _, binding := range actions.bindings[action] {
+		total += binding.value(actions.input)
+	}
+
+	return maths.Clamp(total, -1, 1)
+}
+
+// Pressed tells if any of the action's bindings are held, or pushed past the
+// ActionThreshold in either direction, so that bindings with a negative scale
+// can be pressed too.
+func (actions *ActionMap) Pressed(action string) bool {
+	for _, binding := range actions.bindings[action] {
+		if maths.Abs(binding.value(actions.input)) >= ActionThreshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// JustPressed tells if any of the action's keys or buttons went down since the
+// previous frame, even if they have come back up already, or if it's axes were
+// pushed past the ActionThreshold.
+func (actions *ActionMap) JustPressed(action string) bool {
+	for _, binding := range actions.bindings[action] {
+		if !binding.analog() && binding.edge(actions.input, true) {
+			return true
+		}
+	}
+
+	return actions.tilted(action) && !actions.previous[action]
+}
+
+// JustReleased tells if the action is no longer pressed, and any of it's keys
+// or buttons came up since the previous frame, or it's axes fell back below the
+// ActionThreshold.
+func (actions *ActionMap) JustReleased(action string) bool {
+	if actions.Pressed(action) {
+		return false
+	}
+
+	for _, binding := range actions.bindings[action] {
+		if !binding.analog() && binding.edge(actions.input, false) {
+			return true
+		}
+	}
+
+	return actions.previous[action]
+}
+
+// tilted tells if any of the action's axes are pushed past the ActionThreshold.
+func (actions *ActionMap) tilted(action string) bool {
+	for _, binding := range actions.bindings[action] {
+		if binding.analog() && maths.Abs(binding.value(actions.input)) >= ActionThreshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Load will replace all bindings with those read from the JSON document, which
+// maps each action to a list of bindings, as written by Save.
+func (actions *ActionMap) Load(reader io.Reader) error {
+	var bindings map[string][]Binding
+
+	if err := json.NewDecoder(reader).Decode(&bindings); err != nil {
+		return err
+	}
+
+	actions.bindings = make(map[string][]Binding)
+	actions.previous = make(map[string]bool)
+
+	for action, list := range bindings {
+		actions.Bind(action, list...)
+	}
+
+	return nil
+}
+
+// Save will write all bindings as a JSON document.
+func (actions *ActionMap) Save(writer io.Writer) error {
+	var encoder = json.NewEncoder(writer)
+
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(actions.bindings)
+}
+
+// LoadFile will replace all bindings with those in the JSON file at the path.
+func (actions *ActionMap) LoadFile(path string) error {
+	var file, err = os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return actions.Load(file)
+}
+
+// SaveFile will write all bindings to a JSON file at the path.
+func (actions *ActionMap) SaveFile(path string) error {
+	var file, err = os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err = actions.Save(file); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// advance will remember which actions had an axis pushed past the threshold
+// during the frame, as keys and buttons track their own edges.
+func (actions *ActionMap) advance() {
+	for action := range actions.bindings {
+		actions.previous[action] = actions.tilted(action)
+	}
+}
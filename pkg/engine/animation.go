@@ -0,0 +1,302 @@
+package engine
+
+import (
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// Playback is how a clip carries on once it reaches it's last frame.
+type Playback int
+
+// Playbacks available to animation clips.
+const (
+	// Loop starts the clip again from it's first frame.
+	Loop Playback = iota
+
+	// PingPong plays the clip backwards to it's first frame, then forwards
+	// again, and so on.
+	PingPong
+
+	// Once stops on the last frame, and the clip is finished.
+	Once
+)
+
+// AnimationFrame is a frame of a sprite sheet shown as part of a clip.
+type AnimationFrame struct {
+	// Index of the frame in the sprite sheet.
+	Index int
+
+	// Duration the frame is shown for, in seconds.
+	Duration float32
+
+	// Event is emitted as an AnimationEvent each time the frame is shown,
+	// unless it is empty.
+	Event string
+}
+
+// AnimationClip is a named sequence of frames from a sprite sheet.
+type AnimationClip struct {
+	Name     string
+	Frames   []AnimationFrame
+	Playback Playback
+}
+
+// CreateClip returns a clip showing the frames of a sprite sheet in order, each
+// for the same amount of time given as frames per second.
+func CreateClip(name string, fps float32, playback Playback, frames ...int) *AnimationClip {
+	var clip = &AnimationClip{Name: name, Playback: playback}
+
+	for _, index := range frames {
+		clip.Frames = append(clip.Frames, AnimationFrame{Index: index, Duration: 1 / fps})
+	}
+
+	return clip
+}
+
+// On will emit the event whenever the clip's frame at the position is shown,
+// such as to play a footstep as a foot touches the ground.
+func (clip *AnimationClip) On(position int, event string) *AnimationClip {
+	if position >= 0 && position < len(clip.Frames) {
+		clip.Frames[position].Event = event
+	}
+
+	return clip
+}
+
+// Duration returns how long it takes to play through the clip once.
+func (clip *AnimationClip) Duration() float32 {
+	var duration float32
+
+	for _, frame := range clip.Frames {
+		duration += frame.Duration
+	}
+
+	return duration
+}
+
+// AddClip will add the clip to the sheet, replacing any with the same name.
+func (sheet *SpriteSheet) AddClip(clip *AnimationClip) {
+	if nil == sheet.clips {
+		sheet.clips = make(map[string]*AnimationClip)
+	}
+
+	sheet.clips[clip.Name] = clip
+}
+
+// Clip returns the sheet's clip with the name.
+func (sheet *SpriteSheet) Clip(name string) (*AnimationClip, bool) {
+	var clip, ok = sheet.clips[name]
+
+	return clip, ok
+}
+
+// AnimationEvent is emitted when an animator shows a frame that has an event.
+type AnimationEvent struct {
+	Entity ecs.Entity
+	Clip   string
+	Event  string
+}
+
+// Name of the event.
+func (AnimationEvent) Name() string {
+	return "animation_event"
+}
+
+// AnimationFinished is emitted when an animator reaches the end of a clip that
+// is only played once.
+type AnimationFinished struct {
+	Entity ecs.Entity
+	Clip   string
+}
+
+// Name of the event.
+func (AnimationFinished) Name() string {
+	return "animation_finished"
+}
+
+// Animator plays a clip on an entity's sprite, changing it's frame over time.
+type Animator struct {
+	Clip   *AnimationClip
+	Speed  float32
+	Paused bool
+
+	position  int
+	direction int
+	elapsed   float32
+	started   bool
+	finished  bool
+}
+
+// CreateAnimator returns an animator that plays the clip at normal speed from
+// it's first frame.
+func CreateAnimator(clip *AnimationClip) *Animator {
+	var animator = &Animator{Speed: 1}
+
+	animator.Play(clip)
+
+	return animator
+}
+
+// Name of the component.
+func (Animator) Name() string {
+	return "animator"
+}
+
+// Play will switch to the clip, starting it from it's first frame. Playing the
+// clip that is already playing carries on with it instead.
+func (animator *Animator) Play(clip *AnimationClip) {
+	if clip == animator.Clip && animator.started {
+		return
+	}
+
+	animator.Clip = clip
+
+	animator.Restart()
+}
+
+// Restart will play the clip again from it's first frame.
+func (animator *Animator) Restart() {
+	animator.position = 0
+	animator.direction = 1
+	animator.elapsed = 0
+	animator.started = false
+	animator.finished = false
+}
+
+// Position returns how far through the clip's frames the animator is.
+func (animator *Animator) Position() int {
+	return animator.position
+}
+
+// Finished tells if the clip was played once to it's end.
+func (animator *Animator) Finished() bool {
+	return animator.finished
+}
+
+// advance will move the animator through the clip by the time given, calling
+// shown with each frame it moves to. It returns true if the clip finished.
+func (animator *Animator) advance(dt float32, shown func(AnimationFrame)) bool {
+	var clip = animator.Clip
+
+	if nil == clip || 0 == len(clip.Frames) || animator.finished {
+		return false
+	}
+
+	if !animator.started {
+		animator.started = true
+
+		shown(clip.Frames[animator.position])
+	}
+
+	if animator.Paused {
+		return false
+	}
+
+	animator.elapsed += dt * animator.Speed
+
+	for {
+		var duration = clip.Frames[animator.position].Duration
+
+		// frames without a duration are held, rather than skipped forever
+		if duration <= 0 || animator.elapsed < duration {
+			return false
+		}
+
+		animator.elapsed -= duration
+
+		if !animator.step() {
+			animator.finished = true
+			animator.elapsed = 0
+
+			return true
+		}
+
+		shown(clip.Frames[animator.position])
+	}
+}
+
+// step will move to the next frame, returning false if there are none left.
+func (animator *Animator) step() bool {
+	var count = len(animator.Clip.Frames)
+
+	switch animator.Clip.Playback {
+	case Once:
+		if animator.position == count-1 {
+			return false
+		}
+
+		animator.position++
+	case PingPong:
+		if 1 == count {
+			return true
+		}
+
+		var next = animator.position + animator.direction
+
+		if next < 0 || next >= count {
+			animator.direction = -animator.direction
+			next = animator.position + animator.direction
+		}
+
+		animator.position = next
+	default:
+		animator.position = (animator.position + 1) % count
+	}
+
+	return true
+}
+
+// AnimationSystem plays the clip of every entity with an Animator, showing each
+// frame on the entity's Sprite. It is also a plugin, registering the
+// components, events, and itself. Update it each frame before the sprites are
+// drawn.
+type AnimationSystem struct {
+	ecs.SystemAccess
+}
+
+// CreateAnimationSystem returns a new animation system.
+func CreateAnimationSystem() *AnimationSystem {
+	return new(AnimationSystem)
+}
+
+// Name of the system and plugin.
+func (system *AnimationSystem) Name() string {
+	return "animation"
+}
+
+// Dependencies of the plugin.
+func (system *AnimationSystem) Dependencies() []string {
+	return nil
+}
+
+// Build will register the animator and sprite components, the animation
+// events, and the system.
+func (system *AnimationSystem) Build(world ecs.World) {
+	var animator = Animator{}.Name()
+	var sprite = Sprite{}.Name()
+
+	world.RegisterComponent(animator)
+	world.RegisterComponent(sprite)
+	world.RegisterEvent(AnimationEvent{}.Name())
+	world.RegisterEvent(AnimationFinished{}.Name())
+	world.RegisterSystem(system, animator, sprite)
+}
+
+// Update will advance every animator by the time given.
+func (system *AnimationSystem) Update(dt float32) {
+	for _, entity := range system.Entities() {
+		var animator = system.Component(entity, Animator{}.Name()).(*Animator)
+		var sprite = system.Component(entity, Sprite{}.Name()).(*Sprite)
+
+		var finished = animator.advance(dt, func(frame AnimationFrame) {
+			sprite.Frame = frame.Index
+
+			if "" != frame.Event {
+				system.World().Emit(AnimationEvent{entity, animator.Clip.Name, frame.Event})
+			}
+		})
+
+		if finished {
+			system.World().Emit(AnimationFinished{entity, animator.Clip.Name})
+		}
+	}
+}
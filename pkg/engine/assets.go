@@ -0,0 +1,321 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+)
+
+// AssetHandle refers to an asset loaded by the asset manager. Handles are small
+// values that can be stored in components and copied freely, and are resolved
+// to the asset itself when it is needed.
+type AssetHandle interface {
+	handle() uint32
+}
+
+// TextureHandle refers to a texture loaded by the asset manager.
+type TextureHandle uint32
+
+// FontHandle refers to a font loaded by the asset manager.
+type FontHandle uint32
+
+// SoundHandle refers to a sound loaded by the asset manager.
+type SoundHandle uint32
+
+// MusicHandle refers to music loaded by the asset manager.
+type MusicHandle uint32
+
+func (handle TextureHandle) handle() uint32 { return uint32(handle) }
+func (handle FontHandle) handle() uint32    { return uint32(handle) }
+func (handle SoundHandle) handle() uint32   { return uint32(handle) }
+func (handle MusicHandle) handle() uint32   { return uint32(handle) }
+
+// asset is a single loaded asset and the number of references to it.
+type asset struct {
+	kind       string
+	key        string
+	references int
+	value      interface{}
+	unload     func()
+	loading    bool
+}
+
+// AssetManager loads each asset only once, no matter how many times it is
+// asked for or how it's path is written, and unloads it once nothing refers to
+// it anymore. Every load adds a reference to the asset, which is given back
+// with Release.
+//
+// It is added to every world as a resource named "assets", and any assets
+// still referenced when the engine stops are reported as leaks.
+type AssetManager struct {
+	engine  *Engine
+	assets  map[uint32]*asset
+	keys    map[string]uint32
+	next    uint32
+	results chan loaded
+	workers chan struct{}
+	cancel  chan struct{}
+	loaded  int
+	total   int
+}
+
+// CreateAssetManager returns an asset manager that loads assets through the
+// engine.
+func CreateAssetManager(engine *Engine) *AssetManager {
+	return &AssetManager{
+		engine:  engine,
+		assets:  make(map[uint32]*asset),
+		keys:    make(map[string]uint32),
+		results: make(chan loaded, 64),
+		workers: make(chan struct{}, runtime.NumCPU()),
+		cancel:  make(chan struct{}),
+	}
+}
+
+// Name of the assets resource.
+func (manager *AssetManager) Name() string {
+	return "assets"
+}
+
+// LoadTexture will load the texture at the path, or add a reference to it if it
+// is already loaded.
+func (manager *AssetManager) LoadTexture(path string) (TextureHandle, error) {
+	path = cleanPath(path)
+
+	var id, err = manager.load("texture", path, func() (interface{}, func(), error) {
+		var texture, err = manager.engine.LoadTexture(path)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return texture, texture.Destroy, nil
+	})
+
+	return TextureHandle(id), err
+}
+
+// LoadFont will load the font at the path and point size, or add a reference to
+// it if it is already loaded.
+func (manager *AssetManager) LoadFont(path string, size int) (FontHandle, error) {
+	path = cleanPath(path)
+
+	var id, err = manager.load("font", fmt.Sprintf("%s@%d", path, size), func() (interface{}, func(), error) {
+		var font, err = manager.engine.LoadFont(path, size)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return font, func() {
+			manager.engine.forget(font)
+			font.Close()
+		}, nil
+	})
+
+	return FontHandle(id), err
+}
+
+// LoadSound will load the sound at the path, or add a reference to it if it is
+// already loaded.
+func (manager *AssetManager) LoadSound(path string) (SoundHandle, error) {
+	path = cleanPath(path)
+
+	var id, err = manager.load("sound", path, func() (interface{}, func(), error) {
+		var sound, err = manager.engine.LoadSound(path)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sound, sound.Destroy, nil
+	})
+
+	return SoundHandle(id), err
+}
+
+// LoadMusic will load the music at the path, or add a reference to it if it is
+// already loaded.
+func (manager *AssetManager) LoadMusic(path string) (MusicHandle, error) {
+	path = cleanPath(path)
+
+	var id, err = manager.load("music", path, func() (interface{}, func(), error) {
+		var music, err = manager.engine.LoadMusic(path)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return music, music.Destroy, nil
+	})
+
+	return MusicHandle(id), err
+}
+
+// Texture returns the texture the handle refers to, or nil if it was unloaded.
+func (manager *AssetManager) Texture(handle TextureHandle) Texture {
+	var texture, _ = manager.value(handle).(Texture)
+
+	return texture
+}
+
+// Font returns the font the handle refers to, or nil if it was unloaded.
+func (manager *AssetManager) Font(handle FontHandle) Font {
+	var font, _ = manager.value(handle).(Font)
+
+	return font
+}
+
+// Sound returns the sound the handle refers to, or nil if it was unloaded.
+func (manager *AssetManager) Sound(handle SoundHandle) Sound {
+	var sound, _ = manager.value(handle).(Sound)
+
+	return sound
+}
+
+// Music returns the music the handle refers to, or nil if it was unloaded.
+func (manager *AssetManager) Music(handle MusicHandle) Music {
+	var music, _ = manager.value(handle).(Music)
+
+	return music
+}
+
+// Loaded tells if the asset the handle refers to is still loaded. Assets being
+// loaded in the background are not loaded until they are ready to use.
+func (manager *AssetManager) Loaded(handle AssetHandle) bool {
+	var asset, loaded = manager.assets[handle.handle()]
+
+	return loaded && !asset.loading
+}
+
+// References returns the number of references to the asset.
+func (manager *AssetManager) References(handle AssetHandle) int {
+	if asset, ok := manager.assets[handle.handle()]; ok {
+		return asset.references
+	}
+
+	return 0
+}
+
+// Retain will add a reference to the asset, such as when another component
+// starts using it.
+func (manager *AssetManager) Retain(handle AssetHandle) {
+	if asset, ok := manager.assets[handle.handle()]; ok {
+		asset.references++
+	}
+}
+
+// Release will give back a reference to the asset, unloading it once there are
+// none left.
+func (manager *AssetManager) Release(handle AssetHandle) {
+	var id = handle.handle()
+	var asset, ok = manager.assets[id]
+
+	if !ok {
+		return
+	}
+
+	asset.references--
+
+	if asset.references > 0 {
+		return
+	}
+
+	manager.unload(id)
+}
+
+// Leaks describes every asset that is still loaded.
+func (manager *AssetManager) Leaks() []string {
+	var leaks []string
+
+	for _, asset := range manager.assets {
+		leaks = append(leaks, fmt.Sprintf("%s %s (%d references)", asset.kind, asset.key, asset.references))
+	}
+
+	sort.Strings(leaks)
+
+	return leaks
+}
+
+// Report will write every leaked asset to the writer, one per line.
+func (manager *AssetManager) Report(writer io.Writer) {
+	for _, leak := range manager.Leaks() {
+		fmt.Fprintf(writer, "Leaked %s\n", leak)
+	}
+}
+
+// UnloadAll will unload every asset, no matter how many references it has.
+// Handles to them are no longer valid afterwards. Assets still loading in the
+// background are cancelled, waiting for any workers already loading them.
+func (manager *AssetManager) UnloadAll() {
+	close(manager.cancel)
+
+	// results are thrown away rather than finished, since they would only be
+	// unloaded again
+	for manager.Loading() {
+		<-manager.results
+		manager.loaded++
+	}
+
+	manager.loaded, manager.total = 0, 0
+	manager.cancel = make(chan struct{})
+
+	for id := range manager.assets {
+		manager.unload(id)
+	}
+}
+
+// load will add a reference to the asset of the kind with the given key,
+// loading it first if needed.
+func (manager *AssetManager) load(kind, key string, loader func() (interface{}, func(), error)) (uint32, error) {
+	var cacheKey = kind + ":" + key
+
+	if id, ok := manager.keys[cacheKey]; ok {
+		var existing = manager.assets[id]
+
+		// finish loading it here rather than hand back an asset that isn't
+		// ready yet, and load it again below if that failed
+		for existing.loading && existing == manager.assets[id] {
+			manager.finish(<-manager.results)
+		}
+
+		if existing == manager.assets[id] {
+			existing.references++
+
+			return id, nil
+		}
+	}
+
+	var value, unload, err = loader()
+
+	if err != nil {
+		return 0, err
+	}
+
+	manager.next++
+	manager.assets[manager.next] = &asset{kind, key, 1, value, unload, false}
+	manager.keys[cacheKey] = manager.next
+
+	return manager.next, nil
+}
+
+func (manager *AssetManager) value(handle AssetHandle) interface{} {
+	if asset, ok := manager.assets[handle.handle()]; ok {
+		return asset.value
+	}
+
+	return nil
+}
+
+func (manager *AssetManager) unload(id uint32) {
+	var asset = manager.assets[id]
+
+	// assets still loading are unloaded as soon as they are ready instead
+	if !asset.loading {
+		asset.unload()
+	}
+
+	delete(manager.assets, id)
+	delete(manager.keys, asset.kind+":"+asset.key)
+}
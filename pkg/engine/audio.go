@@ -0,0 +1,315 @@
+package engine
+
+import (
+	"os"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// DefaultChannels is the number of sounds that can play at the same time.
+const DefaultChannels = 16
+
+// AnyChannel plays a sound on the first channel that is free.
+const AnyChannel = -1
+
+// Forever loops a sound or music until it is stopped.
+const Forever = -1
+
+// Sound is a short sound effect, decoded into memory so that it can be played
+// many times at once.
+type Sound interface {
+	// Destroy will free the sound.
+	Destroy()
+}
+
+// Music is a long piece of audio streamed as it plays. Only one can play at a
+// time.
+type Music interface {
+	// Destroy will free the music.
+	Destroy()
+}
+
+// Mixer is the platform layer that plays sounds and music. Sounds play on
+// numbered channels, each of which has it's own volume and panning. Volumes
+// range from 0 to 1, pans from -1 (left) to 1 (right), and fades are given in
+// seconds.
+type Mixer interface {
+	// Open will start the audio device with the given number of channels.
+	Open(channels int) error
+
+	// Close will stop everything that is playing and close the audio device.
+	Close()
+
+	// LoadSound will decode the contents of a WAV, OGG, or MP3 file into a new
+	// sound.
+	LoadSound(data []byte) (Sound, error)
+
+	// LoadMusic will open the contents of a music file to be streamed. The data
+	// must not be changed while the music is loaded.
+	LoadMusic(data []byte) (Music, error)
+
+	// Play will play the sound on the channel, or AnyChannel, returning the
+	// channel used. It is played once more for each loop, or Forever.
+	Play(sound Sound, channel, loops int, fade float32) (int, error)
+
+	// Stop will stop the channel, fading it out over the given time.
+	Stop(channel int, fade float32)
+
+	// Pause will pause the channel.
+	Pause(channel int)
+
+	// Resume will continue playing a paused channel.
+	Resume(channel int)
+
+	// Playing tells if the channel is currently playing.
+	Playing(channel int) bool
+
+	// Volume sets the channel's volume.
+	Volume(channel int, volume float32)
+
+	// Pan sets how far to the left or right the channel is heard. Where the
+	// audio device cannot pan, the channel is heard from the centre.
+	Pan(channel int, pan float32)
+
+	// PlayMusic will play the music in place of any that is playing.
+	PlayMusic(music Music, loops int, fade float32) error
+
+	// StopMusic will stop the music, fading it out over the given time.
+	StopMusic(fade float32)
+
+	// PauseMusic will pause the music.
+	PauseMusic()
+
+	// ResumeMusic will continue playing paused music.
+	ResumeMusic()
+
+	// MusicVolume sets the music's volume.
+	MusicVolume(volume float32)
+}
+
+// AudioSource plays a sound from an entity. Set Play to start the sound, and
+// Stop to stop it, and the audio system will clear them once done. Changes to
+// the volume and pan are heard while the sound plays. If the sound could not be
+// played, such as when every channel is busy, Err records why until it is next
+// played.
+type AudioSource struct {
+	Sound   Sound
+	Volume  float32
+	Pan     float32
+	Loops   int
+	FadeIn  float32
+	FadeOut float32
+	Play    bool
+	Stop    bool
+	Err     error
+
+	channel int
+	playing bool
+}
+
+// CreateAudioSource returns a source that plays the sound once at full volume
+// as soon as it is attached.
+func CreateAudioSource(sound Sound) *AudioSource {
+	return &AudioSource{Sound: sound, Volume: 1, Play: true}
+}
+
+// Name of the component.
+func (AudioSource) Name() string {
+	return "audio_source"
+}
+
+// Playing tells if the source's sound is playing.
+func (source *AudioSource) Playing() bool {
+	return source.playing
+}
+
+// AudioSystem plays the sounds of every entity with an AudioSource. It is also
+// a plugin, registering the component and itself, and is updated by the engine
+// every frame once audio is opened.
+//
+// Once a source's sound ends, it's channel may be given to another source, so
+// the system remembers which source owns each channel and never changes or
+// stops a channel on behalf of a source that no longer owns it.
+type AudioSystem struct {
+	ecs.SystemAccess
+
+	mixer  Mixer
+	owners map[int]*AudioSource
+}
+
+// CreateAudioSystem returns an audio system that plays through the mixer.
+func CreateAudioSystem(mixer Mixer) *AudioSystem {
+	return &AudioSystem{mixer: mixer, owners: make(map[int]*AudioSource)}
+}
+
+// Name of the system and plugin.
+func (system *AudioSystem) Name() string {
+	return "audio"
+}
+
+// Dependencies of the plugin.
+func (system *AudioSystem) Dependencies() []string {
+	return nil
+}
+
+// Build will register the audio source component and the system.
+func (system *AudioSystem) Build(world ecs.World) {
+	var source = AudioSource{}.Name()
+
+	world.RegisterComponent(source)
+	world.RegisterSystem(system, source)
+}
+
+// Update will start and stop sources as asked, and keep the volume and pan of
+// playing sources up to date.
+func (system *AudioSystem) Update(dt float32) {
+	for _, entity := range system.Entities() {
+		var source = system.Component(entity, AudioSource{}.Name()).(*AudioSource)
+
+		if source.Stop {
+			system.silence(source)
+
+			source.Stop = false
+		}
+
+		if source.Play {
+			system.silence(source)
+			system.play(source)
+
+			source.Play = false
+		}
+
+		if !system.owns(source) {
+			continue
+		}
+
+		if !system.mixer.Playing(source.channel) {
+			system.release(source)
+
+			continue
+		}
+
+		system.mixer.Volume(source.channel, source.Volume)
+		system.mixer.Pan(source.channel, source.Pan)
+	}
+}
+
+// Unsubscribe will stop the entity's sound, such as when it is destroyed.
+func (system *AudioSystem) Unsubscribe(entity ecs.Entity) {
+	if source, ok := system.Component(entity, AudioSource{}.Name()).(*AudioSource); ok {
+		system.silence(source)
+	}
+
+	system.SystemAccess.Unsubscribe(entity)
+}
+
+func (system *AudioSystem) play(source *AudioSource) {
+	if nil == source.Sound {
+		return
+	}
+
+	var channel, err = system.mixer.Play(source.Sound, AnyChannel, source.Loops, source.FadeIn)
+	source.Err = err
+
+	if err != nil {
+		return
+	}
+
+	// the channel's previous source finished, so it no longer plays
+	if previous, ok := system.owners[channel]; ok {
+		previous.playing = false
+	}
+
+	system.owners[channel] = source
+	source.channel = channel
+	source.playing = true
+
+	system.mixer.Volume(channel, source.Volume)
+	system.mixer.Pan(channel, source.Pan)
+}
+
+func (system *AudioSystem) silence(source *AudioSource) {
+	if !system.owns(source) {
+		source.playing = false
+
+		return
+	}
+
+	system.mixer.Stop(source.channel, source.FadeOut)
+	system.release(source)
+}
+
+// owns tells if the source is playing on a channel that is still it's own.
+func (system *AudioSystem) owns(source *AudioSource) bool {
+	return source.playing && source == system.owners[source.channel]
+}
+
+// release will give up the source's channel.
+func (system *AudioSystem) release(source *AudioSource) {
+	if source == system.owners[source.channel] {
+		delete(system.owners, source.channel)
+	}
+
+	source.playing = false
+}
+
+// Audio returns the engine's mixer, for playing sounds and music directly.
+func (engine *Engine) Audio() Mixer {
+	return engine.mixer
+}
+
+// OpenAudio will start the audio device, and add the audio system to the world
+// so that AudioSource components are played. It must be called after Init.
+// When headless, SDL's dummy audio driver is used, so that audio can be tested
+// without a sound card.
+func (engine *Engine) OpenAudio() error {
+	if engine.headless {
+		os.Setenv("SDL_AUDIODRIVER", "dummy")
+	}
+
+	if err := engine.mixer.Open(DefaultChannels); err != nil {
+		return err
+	}
+
+	engine.audio = CreateAudioSystem(engine.mixer)
+
+	return engine.world.AddPlugins(engine.audio)
+}
+
+// LoadSound will decode the sound effect file at the given path.
+func (engine *Engine) LoadSound(path string) (Sound, error) {
+	var data, err = engine.read("sound", path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var sound Sound
+
+	sound, err = engine.mixer.LoadSound(data)
+
+	if err != nil {
+		return nil, assetError("sound", path, err)
+	}
+
+	return sound, nil
+}
+
+// LoadMusic will open the music file at the given path.
+func (engine *Engine) LoadMusic(path string) (Music, error) {
+	var data, err = engine.read("music", path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var music Music
+
+	music, err = engine.mixer.LoadMusic(data)
+
+	if err != nil {
+		return nil, assetError("music", path, err)
+	}
+
+	return music, nil
+}
@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"errors"
+	"image"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// ErrUnsupported is returned by backends that are unable to perform the
+// requested operation, such as loading fonts without a font rasterizer.
+var ErrUnsupported = errors.New("operation not supported by this backend")
+
+// Backend is the platform layer that the engine opens windows, creates
+// textures, and draws to the screen through. Programs written against the
+// engine never need to know which backend is in use.
+type Backend interface {
+	// Open will create the window, if any, along with anything else the backend
+	// needs to begin drawing.
+	Open(title string, width, height int32) error
+
+	// VSync sets whether presenting waits for the display to refresh. It is
+	// called before the backend is opened.
+	VSync(enabled bool)
+
+	// Resizable sets whether the user can resize the window. It may be called
+	// before the backend is opened.
+	Resizable(enabled bool)
+
+	// Display will switch the window between windowed, fullscreen, and
+	// borderless fullscreen. It may be called before the backend is opened.
+	Display(mode WindowMode) error
+
+	// Logical sets the resolution that is drawn to, which is scaled to fit the
+	// window and letterboxed to keep it's aspect ratio, optionally only by
+	// whole numbers. A zero size draws at the window's own size. It may be
+	// called before the backend is opened.
+	Logical(width, height int32, integer bool) error
+
+	// Close will destroy the window and free all of the backend's resources.
+	Close()
+
+	// Poll will process pending operating system events, recording keyboard,
+	// mouse, and gamepad events to the input state, and changes to the window
+	// to the window state. It returns false when the user has asked to quit.
+	Poll(input *InputState, window *WindowState) bool
+
+	// LoadGamepadMappings will add the gamepad mappings in the file contents, in the
+	// format of the community SDL_GameControllerDB, so that more gamepads are
+	// recognized.
+	LoadGamepadMappings(data []byte) error
+
+	// AttachVirtualGamepad will plug in a gamepad that exists only in software.
+	AttachVirtualGamepad() (VirtualGamepad, error)
+
+	// Clear will wipe the screen, ready for the next frame to be drawn.
+	Clear()
+
+	// Blit will copy the source area of the texture to the destination area of
+	// the screen, scaling it to fit. A nil area is the entire texture or screen.
+	Blit(texture Texture, source, destination *Region)
+
+	// BlitEx will blit the same as Blit, turning the texture clockwise by the
+	// angle in radians around the centre of the destination, and flipping it.
+	BlitEx(texture Texture, source, destination *Region, angle float32, flip Flip)
+
+	// Present will display everything blitted since the screen was cleared.
+	Present()
+
+	// Alert will show the message to the user, such as in a dialog box.
+	Alert(title, message string) error
+
+	// CreateTexture will create an empty texture that can have pixels streamed
+	// to it.
+	CreateTexture(width, height int32) (Texture, error)
+
+	// LoadTexture will create a new texture from the contents of an image file.
+	LoadTexture(data []byte) (Texture, error)
+
+	// UploadTexture will create a new texture from an image that has already
+	// been decoded, such as by a worker goroutine.
+	UploadTexture(pixels image.Image) (Texture, error)
+
+	// LoadFont will open the contents of a font file at the given point size.
+	// The data must not be changed while the font is open.
+	LoadFont(data []byte, size int) (Font, error)
+
+	// RenderText will draw the text with the font to a new texture.
+	RenderText(font Font, text string, colour ecs.Colour) (Texture, error)
+}
+
+// Texture is an image that lives with the backend and can be blitted to the
+// screen.
+type Texture interface {
+	// Size returns the width and height of the texture in pixels.
+	Size() (width, height int32)
+
+	// Update will replace the texture's pixels, given four bytes per pixel in
+	// RGBA order, row by row from the top left.
+	Update(pixels []byte) error
+
+	// Tint will multiply the colour of the texture's pixels by the given colour
+	// whenever it is blitted. White leaves them unchanged.
+	Tint(colour ecs.Colour)
+
+	// Destroy will free the texture.
+	Destroy()
+}
+
+// Font is a typeface at a particular size that can be used to render text.
+type Font interface {
+	// Height returns the height of a line of text in pixels.
+	Height() int32
+
+	// Advance returns how far along the line the character moves the next one.
+	Advance(char rune) int32
+
+	// Glyph will draw the character in white to a new image, as tall as a line
+	// of text, with the character's coverage given by the alpha of each pixel.
+	Glyph(char rune) (image.Image, error)
+
+	// Close will free the font.
+	Close()
+}
+
+// Flip mirrors a texture when it is blitted. Flips can be combined, such as
+// FlipHorizontal | FlipVertical.
+type Flip int
+
+// Flips available to BlitEx.
+const (
+	FlipNone       Flip = 0
+	FlipHorizontal Flip = 1
+	FlipVertical   Flip = 2
+)
+
+// Region is an area of a texture or the screen in whole pixels, starting from
+// it's top left corner.
+type Region struct {
+	X, Y, W, H int32
+}
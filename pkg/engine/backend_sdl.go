@@ -0,0 +1,527 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+	"github.com/veandco/go-sdl2/img"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// CreateSDLBackend returns a backend that draws to a hardware accelerated SDL2
+// window. This is the default backend.
+func CreateSDLBackend() Backend {
+	return new(sdlBackend)
+}
+
+// sdlBackend encapsulates a window and renderer, supplying a clean interface
+// to control the content on the screen through textures.
+type sdlBackend struct {
+	window      *sdl.Window
+	renderer    *sdl.Renderer
+	controllers map[sdl.JoystickID]*sdl.GameController
+	vsync       bool
+	resizable   bool
+	mode        WindowMode
+	logical     Region
+	integer     bool
+}
+
+type sdlTexture struct {
+	texture       *sdl.Texture
+	width, height int32
+}
+
+type sdlFont struct {
+	font *ttf.Font
+	data []byte // read from for as long as the font is open
+}
+
+// sdlGamepad is a virtual joystick laid out like a game controller.
+type sdlGamepad struct {
+	index    int
+	joystick *sdl.Joystick
+}
+
+func (backend *sdlBackend) Open(title string, width, height int32) error {
+	var err error
+
+	if err = sdl.Init(sdl.INIT_VIDEO | sdl.INIT_GAMECONTROLLER); err != nil {
+		return err
+	}
+
+	if err = ttf.Init(); err != nil {
+		return err
+	}
+
+	var windowFlags uint32 = sdl.WINDOW_SHOWN | fullscreenFlags(backend.mode)
+
+	if backend.resizable {
+		windowFlags |= sdl.WINDOW_RESIZABLE
+	}
+
+	backend.window, err = sdl.CreateWindow(
+		title,
+		sdl.WINDOWPOS_CENTERED,
+		sdl.WINDOWPOS_CENTERED,
+		width,
+		height,
+		windowFlags,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	var flags uint32 = sdl.RENDERER_ACCELERATED
+
+	if backend.vsync {
+		flags |= sdl.RENDERER_PRESENTVSYNC
+	}
+
+	backend.renderer, err = sdl.CreateRenderer(backend.window, -1, flags)
+
+	if err != nil {
+		return err
+	}
+
+	return backend.Logical(backend.logical.W, backend.logical.H, backend.integer)
+}
+
+func (backend *sdlBackend) VSync(enabled bool) {
+	backend.vsync = enabled
+}
+
+func (backend *sdlBackend) Resizable(enabled bool) {
+	backend.resizable = enabled
+
+	if nil != backend.window {
+		backend.window.SetResizable(enabled)
+	}
+}
+
+func (backend *sdlBackend) Display(mode WindowMode) error {
+	backend.mode = mode
+
+	if nil == backend.window {
+		return nil
+	}
+
+	return backend.window.SetFullscreen(fullscreenFlags(mode))
+}
+
+// Logical has the renderer scale everything drawn to fit the window, which SDL
+// letterboxes to keep the aspect ratio.
+func (backend *sdlBackend) Logical(width, height int32, integer bool) error {
+	backend.logical = Region{W: width, H: height}
+	backend.integer = integer
+
+	if nil == backend.renderer {
+		return nil
+	}
+
+	if err := backend.renderer.SetLogicalSize(width, height); err != nil {
+		return err
+	}
+
+	return backend.renderer.SetIntegerScale(integer)
+}
+
+// fullscreenFlags converts the window mode to SDL's window flags.
+func fullscreenFlags(mode WindowMode) uint32 {
+	switch mode {
+	case Fullscreen:
+		return sdl.WINDOW_FULLSCREEN
+	case Borderless:
+		return sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+
+	return 0
+}
+
+// Close will cleanup any resources used by the window and renderer.
+func (backend *sdlBackend) Close() {
+	for id, controller := range backend.controllers {
+		controller.Close()
+		delete(backend.controllers, id)
+	}
+
+	backend.renderer.Destroy()
+	backend.window.Destroy()
+	ttf.Quit()
+	sdl.Quit()
+}
+
+func (backend *sdlBackend) Poll(input *InputState, window *WindowState) bool {
+	var open = true
+
+	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+		switch event := event.(type) {
+		case *sdl.QuitEvent:
+			open = false
+		case *sdl.WindowEvent:
+			switch event.Event {
+			case sdl.WINDOWEVENT_SIZE_CHANGED:
+				window.Resize(event.Data1, event.Data2)
+			case sdl.WINDOWEVENT_FOCUS_GAINED:
+				window.Focus(true)
+			case sdl.WINDOWEVENT_FOCUS_LOST:
+				window.Focus(false)
+			}
+		case *sdl.KeyboardEvent:
+			if sdl.PRESSED == event.State {
+				input.Press(Key(event.Keysym.Scancode))
+			} else {
+				input.Release(Key(event.Keysym.Scancode))
+			}
+		case *sdl.MouseButtonEvent:
+			input.MoveMouse(event.X, event.Y, 0, 0)
+
+			if sdl.PRESSED == event.State {
+				input.PressButton(MouseButton(event.Button))
+			} else {
+				input.ReleaseButton(MouseButton(event.Button))
+			}
+		case *sdl.MouseMotionEvent:
+			input.MoveMouse(event.X, event.Y, event.XRel, event.YRel)
+		case *sdl.MouseWheelEvent:
+			input.Scroll(event.X, event.Y)
+		case *sdl.TextInputEvent:
+			input.Type(event.GetText())
+		case *sdl.ControllerDeviceEvent:
+			backend.plug(input, event)
+		case *sdl.ControllerButtonEvent:
+			if gamepad := input.Gamepad(int(event.Which)); nil != gamepad {
+				if sdl.PRESSED == event.State {
+					gamepad.Press(GamepadButton(event.Button))
+				} else {
+					gamepad.Release(GamepadButton(event.Button))
+				}
+			}
+		case *sdl.ControllerAxisEvent:
+			if gamepad := input.Gamepad(int(event.Which)); nil != gamepad {
+				gamepad.Move(GamepadAxis(event.Axis), maths.Max(float32(event.Value)/32767, -1))
+			}
+		}
+	}
+
+	return open
+}
+
+// plug will open newly connected game controllers, and close those that were
+// removed.
+func (backend *sdlBackend) plug(input *InputState, event *sdl.ControllerDeviceEvent) {
+	switch event.Type {
+	case sdl.CONTROLLERDEVICEADDED:
+		// the device index, rather than the instance ID, is given when added
+		var controller = sdl.GameControllerOpen(int(event.Which))
+
+		if nil == controller {
+			return
+		}
+
+		var id = controller.Joystick().InstanceID()
+
+		if nil == backend.controllers {
+			backend.controllers = make(map[sdl.JoystickID]*sdl.GameController)
+		}
+
+		backend.controllers[id] = controller
+
+		input.ConnectGamepad(int(id), controller.Name())
+	case sdl.CONTROLLERDEVICEREMOVED:
+		if controller, ok := backend.controllers[event.Which]; ok {
+			controller.Close()
+			delete(backend.controllers, event.Which)
+		}
+
+		input.DisconnectGamepad(int(event.Which))
+	}
+}
+
+func (backend *sdlBackend) LoadGamepadMappings(data []byte) error {
+	var source, err = sdl.RWFromMem(data)
+
+	if err != nil {
+		return err
+	}
+
+	if sdl.GameControllerAddMappingsFromRW(source, true) < 0 {
+		return fmt.Errorf("unable to load gamepad mappings: %v", sdl.GetError())
+	}
+
+	return nil
+}
+
+// AttachVirtualGamepad will plug in an SDL virtual joystick with the buttons and
+// axes of a game controller.
+func (backend *sdlBackend) AttachVirtualGamepad() (VirtualGamepad, error) {
+	var index, err = sdl.JoystickAttachVirtual(
+		sdl.JOYSTICK_TYPE_GAMECONTROLLER,
+		GamepadAxisCount,
+		int(GamepadDPadRight)+1,
+		0,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var joystick = sdl.JoystickOpen(index)
+
+	if nil == joystick {
+		sdl.JoystickDetachVirtual(index)
+
+		return nil, fmt.Errorf("unable to open virtual gamepad: %v", sdl.GetError())
+	}
+
+	return &sdlGamepad{index, joystick}, nil
+}
+
+func (gamepad *sdlGamepad) Press(button GamepadButton) error {
+	return gamepad.joystick.SetVirtualButton(int(button), sdl.PRESSED)
+}
+
+func (gamepad *sdlGamepad) Release(button GamepadButton) error {
+	return gamepad.joystick.SetVirtualButton(int(button), sdl.RELEASED)
+}
+
+func (gamepad *sdlGamepad) Move(axis GamepadAxis, value float32) error {
+	return gamepad.joystick.SetVirtualAxis(int(axis), int16(maths.Clamp(value, -1, 1)*32767))
+}
+
+func (gamepad *sdlGamepad) Detach() error {
+	gamepad.joystick.Close()
+
+	return sdl.JoystickDetachVirtual(gamepad.index)
+}
+
+// Clear will wipe the renderer's target with the current drawing colour.
+func (backend *sdlBackend) Clear() {
+	backend.renderer.Clear()
+}
+
+// Blit will copy the texture to the renderer's target.
+func (backend *sdlBackend) Blit(texture Texture, source, destination *Region) {
+	backend.renderer.Copy(texture.(*sdlTexture).texture, sdlRect(source), sdlRect(destination))
+}
+
+// BlitEx will copy the texture with SDL's angle given in degrees, and it's flips
+// given in the same bits.
+func (backend *sdlBackend) BlitEx(texture Texture, source, destination *Region, angle float32, flip Flip) {
+	backend.renderer.CopyEx(
+		texture.(*sdlTexture).texture,
+		sdlRect(source),
+		sdlRect(destination),
+		float64(maths.Degrees(angle)),
+		nil,
+		sdl.RendererFlip(flip),
+	)
+}
+
+// Present will show the renderer's content on the screen.
+func (backend *sdlBackend) Present() {
+	backend.renderer.Present()
+}
+
+func (backend *sdlBackend) Alert(title, message string) error {
+	return sdl.ShowSimpleMessageBox(sdl.MESSAGEBOX_ERROR, title, message, backend.window)
+}
+
+// CreateTexture creates a streaming texture in RGBA byte order.
+func (backend *sdlBackend) CreateTexture(width, height int32) (Texture, error) {
+	var texture, err = backend.renderer.CreateTexture(
+		sdl.PIXELFORMAT_ABGR8888,
+		sdl.TEXTUREACCESS_STREAMING,
+		width,
+		height,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdlTexture{texture, width, height}, nil
+}
+
+func (backend *sdlBackend) LoadTexture(data []byte) (Texture, error) {
+	var source, err = sdl.RWFromMem(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var texture *sdl.Texture
+
+	if texture, err = img.LoadTextureRW(backend.renderer, source, true); err != nil {
+		return nil, err
+	}
+
+	return wrapTexture(texture)
+}
+
+// UploadTexture will stream the image's pixels to a new texture that is blended
+// when blitted, the same as a texture loaded from a file.
+func (backend *sdlBackend) UploadTexture(pixels image.Image) (Texture, error) {
+	var bounds = pixels.Bounds()
+	var width, height = int32(bounds.Dx()), int32(bounds.Dy())
+	// SDL expects straight alpha rather than the premultiplied alpha of RGBA
+	var straight = image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	draw.Draw(straight, straight.Bounds(), pixels, bounds.Min, draw.Src)
+
+	var created, err = backend.CreateTexture(width, height)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var texture = created.(*sdlTexture)
+
+	if err = texture.Update(straight.Pix); err != nil {
+		texture.Destroy()
+
+		return nil, err
+	}
+
+	if err = texture.texture.SetBlendMode(sdl.BLENDMODE_BLEND); err != nil {
+		texture.Destroy()
+
+		return nil, err
+	}
+
+	return texture, nil
+}
+
+func (backend *sdlBackend) LoadFont(data []byte, size int) (Font, error) {
+	var source, err = sdl.RWFromMem(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var font *ttf.Font
+
+	if font, err = ttf.OpenFontRW(source, 1, size); err != nil {
+		return nil, err
+	}
+
+	return &sdlFont{font, data}, nil
+}
+
+func (backend *sdlBackend) RenderText(font Font, text string, colour ecs.Colour) (Texture, error) {
+	var surface, err = font.(*sdlFont).font.RenderUTF8Blended(text, sdl.Color{
+		R: colour.Red,
+		G: colour.Green,
+		B: colour.Blue,
+		A: 255,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer surface.Free()
+
+	var texture *sdl.Texture
+
+	if texture, err = backend.renderer.CreateTextureFromSurface(surface); err != nil {
+		return nil, err
+	}
+
+	return wrapTexture(texture)
+}
+
+func (texture *sdlTexture) Size() (int32, int32) {
+	return texture.width, texture.height
+}
+
+func (texture *sdlTexture) Update(pixels []byte) error {
+	if len(pixels) < int(texture.width*texture.height*4) {
+		return errors.New("not enough pixels to update texture")
+	}
+
+	return texture.texture.Update(nil, pixels, int(texture.width*4))
+}
+
+func (texture *sdlTexture) Destroy() {
+	texture.texture.Destroy()
+}
+
+func (texture *sdlTexture) Tint(colour ecs.Colour) {
+	texture.texture.SetColorMod(colour.Red, colour.Green, colour.Blue)
+}
+
+func (font *sdlFont) Height() int32 {
+	return int32(font.font.Height())
+}
+
+func (font *sdlFont) Advance(char rune) int32 {
+	var metrics, err = font.font.GlyphMetrics(char)
+
+	if err != nil {
+		return 0
+	}
+
+	return int32(metrics.Advance)
+}
+
+// Glyph will render the character and copy it's surface to an image, since
+// surfaces can only be read while the font is open.
+func (font *sdlFont) Glyph(char rune) (image.Image, error) {
+	var rendered, err = font.font.RenderGlyphBlended(char, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rendered.Free()
+
+	var surface *sdl.Surface
+
+	// converted to the same byte order as image.NRGBA
+	if surface, err = rendered.ConvertFormat(sdl.PIXELFORMAT_ABGR8888, 0); err != nil {
+		return nil, err
+	}
+
+	defer surface.Free()
+
+	var glyph = image.NewNRGBA(image.Rect(0, 0, int(surface.W), int(surface.H)))
+	var pixels = surface.Pixels()
+
+	for y := 0; y < int(surface.H); y++ {
+		copy(glyph.Pix[y*glyph.Stride:(y+1)*glyph.Stride], pixels[y*int(surface.Pitch):])
+	}
+
+	return glyph, nil
+}
+
+func (font *sdlFont) Close() {
+	font.font.Close()
+}
+
+// wrapTexture queries the size of an SDL texture to wrap it as a Texture.
+func wrapTexture(texture *sdl.Texture) (Texture, error) {
+	var _, _, width, height, err = texture.Query()
+
+	if err != nil {
+		texture.Destroy()
+
+		return nil, err
+	}
+
+	return &sdlTexture{texture, width, height}, nil
+}
+
+func sdlRect(region *Region) *sdl.Rect {
+	if nil == region {
+		return nil
+	}
+
+	return &sdl.Rect{X: region.X, Y: region.Y, W: region.W, H: region.H}
+}
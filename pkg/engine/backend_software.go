@@ -0,0 +1,460 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"os"
+
+	// image formats that can be loaded as textures
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// CreateSoftwareBackend returns a backend that draws to an image in memory
+// using only Go, with no window, keyboard, or fonts. It is used when running
+// headless, and the last presented frame can be read with Frame. Drawing
+// before the backend is opened, or after it is closed, does nothing.
+func CreateSoftwareBackend() *SoftwareBackend {
+	return new(SoftwareBackend)
+}
+
+// SoftwareBackend blits textures to an in-memory image instead of a window.
+type SoftwareBackend struct {
+	screen  *image.RGBA
+	frame   *image.RGBA
+	width   int32
+	height  int32
+	logical Region
+	integer bool
+	pending []func(input *InputState, window *WindowState)
+	gamepad int
+}
+
+// softwareGamepad queues what it does with the backend until the next poll.
+type softwareGamepad struct {
+	backend *SoftwareBackend
+	id      int
+}
+
+// softwareTexture is an image that either replaces the screen's pixels when
+// blitted or is blended over them, matching how SDL treats streaming textures
+// and textures loaded from files.
+type softwareTexture struct {
+	image *image.RGBA
+	blend bool
+	tint  *ecs.Colour
+}
+
+func (backend *SoftwareBackend) Open(title string, width, height int32) error {
+	backend.width = width
+	backend.height = height
+
+	backend.allocate()
+
+	return nil
+}
+
+// allocate will create the frame at the window's size, and the screen at the
+// resolution that is drawn to.
+func (backend *SoftwareBackend) allocate() {
+	var width, height = backend.width, backend.height
+
+	if 0 != backend.logical.W && 0 != backend.logical.H {
+		width, height = backend.logical.W, backend.logical.H
+	}
+
+	backend.screen = image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	backend.frame = image.NewRGBA(image.Rect(0, 0, int(backend.width), int(backend.height)))
+}
+
+// VSync has no effect, since there is no display to wait for.
+func (backend *SoftwareBackend) VSync(enabled bool) {}
+
+// Resizable has no effect, since there is no user to resize the window. Use
+// Resize to simulate one.
+func (backend *SoftwareBackend) Resizable(enabled bool) {}
+
+// Display has no effect, since there is no display.
+func (backend *SoftwareBackend) Display(mode WindowMode) error {
+	return nil
+}
+
+// Logical sets the size of the screen that is drawn to, which is scaled to fit
+// the frame when presented.
+func (backend *SoftwareBackend) Logical(width, height int32, integer bool) error {
+	backend.logical = Region{W: width, H: height}
+	backend.integer = integer
+
+	if nil != backend.frame {
+		backend.allocate()
+	}
+
+	return nil
+}
+
+// Resize simulates the user resizing the window, which arrives on the next
+// poll.
+func (backend *SoftwareBackend) Resize(width, height int32) {
+	backend.pending = append(backend.pending, func(input *InputState, window *WindowState) {
+		backend.width = width
+		backend.height = height
+
+		backend.allocate()
+		window.Resize(width, height)
+	})
+}
+
+// Focus simulates the window gaining or losing focus, which arrives on the next
+// poll.
+func (backend *SoftwareBackend) Focus(focused bool) {
+	backend.pending = append(backend.pending, func(input *InputState, window *WindowState) {
+		window.Focus(focused)
+	})
+}
+
+func (backend *SoftwareBackend) Close() {
+	backend.screen = nil
+	backend.frame = nil
+}
+
+// Poll has no operating system events to process, since there is no window,
+// but delivers anything done by virtual gamepads. Other input can still be
+// simulated by recording it to the engine's input state directly.
+func (backend *SoftwareBackend) Poll(input *InputState, window *WindowState) bool {
+	for _, event := range backend.pending {
+		event(input, window)
+	}
+
+	backend.pending = nil
+
+	return true
+}
+
+// LoadGamepadMappings is unsupported, since there are no physical gamepads to
+// map.
+func (backend *SoftwareBackend) LoadGamepadMappings(data []byte) error {
+	return ErrUnsupported
+}
+
+func (backend *SoftwareBackend) AttachVirtualGamepad() (VirtualGamepad, error) {
+	var gamepad = &softwareGamepad{backend, backend.gamepad}
+
+	backend.gamepad++
+	backend.queue(func(input *InputState) {
+		input.ConnectGamepad(gamepad.id, "Virtual Gamepad")
+	})
+
+	return gamepad, nil
+}
+
+// queue will run the event against the input state on the next poll.
+func (backend *SoftwareBackend) queue(event func(input *InputState)) {
+	backend.pending = append(backend.pending, func(input *InputState, window *WindowState) {
+		event(input)
+	})
+}
+
+func (gamepad *softwareGamepad) Press(button GamepadButton) error {
+	return gamepad.update(func(state *Gamepad) {
+		state.Press(button)
+	})
+}
+
+func (gamepad *softwareGamepad) Release(button GamepadButton) error {
+	return gamepad.update(func(state *Gamepad) {
+		state.Release(button)
+	})
+}
+
+func (gamepad *softwareGamepad) Move(axis GamepadAxis, value float32) error {
+	return gamepad.update(func(state *Gamepad) {
+		state.Move(axis, value)
+	})
+}
+
+func (gamepad *softwareGamepad) Detach() error {
+	gamepad.backend.queue(func(input *InputState) {
+		input.DisconnectGamepad(gamepad.id)
+	})
+
+	return nil
+}
+
+// update will queue a change to the gamepad's state.
+func (gamepad *softwareGamepad) update(change func(state *Gamepad)) error {
+	gamepad.backend.queue(func(input *InputState) {
+		if state := input.Gamepad(gamepad.id); nil != state {
+			change(state)
+		}
+	})
+
+	return nil
+}
+
+// Clear will fill the screen with black.
+func (backend *SoftwareBackend) Clear() {
+	if nil == backend.screen {
+		return
+	}
+
+	for i := range backend.screen.Pix {
+		backend.screen.Pix[i] = 0
+	}
+}
+
+// Blit will copy the texture to the screen using nearest neighbour scaling.
+func (backend *SoftwareBackend) Blit(texture Texture, source, destination *Region) {
+	backend.BlitEx(texture, source, destination, 0, FlipNone)
+}
+
+// BlitEx will copy the texture to the screen using nearest neighbour scaling,
+// turning each pixel of the destination back by the angle to find the pixel of
+// the texture it shows.
+func (backend *SoftwareBackend) BlitEx(texture Texture, source, destination *Region, angle float32, flip Flip) {
+	if nil == backend.screen {
+		return
+	}
+
+	var from = texture.(*softwareTexture)
+	var width, height = from.Size()
+	var screen = backend.screen.Bounds()
+	var src = Region{0, 0, width, height}
+	var dst = Region{0, 0, int32(screen.Dx()), int32(screen.Dy())}
+
+	if nil != source {
+		src = *source
+	}
+
+	if nil != destination {
+		dst = *destination
+	}
+
+	if src.W <= 0 || src.H <= 0 || dst.W <= 0 || dst.H <= 0 {
+		return
+	}
+
+	var area = image.Rect(int(dst.X), int(dst.Y), int(dst.X+dst.W), int(dst.Y+dst.H))
+	var sin, cos = math.Sincos(float64(angle))
+	var halfWidth, halfHeight = float64(dst.W) / 2, float64(dst.H) / 2
+	var centreX, centreY = float64(dst.X) + halfWidth, float64(dst.Y) + halfHeight
+
+	if 0 != angle {
+		// the area covered by the corners once turned
+		var extentX = math.Abs(cos*halfWidth) + math.Abs(sin*halfHeight)
+		var extentY = math.Abs(sin*halfWidth) + math.Abs(cos*halfHeight)
+
+		area = image.Rect(
+			int(math.Floor(centreX-extentX)),
+			int(math.Floor(centreY-extentY)),
+			int(math.Ceil(centreX+extentX)),
+			int(math.Ceil(centreY+extentY)),
+		)
+	}
+
+	area = area.Intersect(screen)
+
+	for py := area.Min.Y; py < area.Max.Y; py++ {
+		for px := area.Min.X; px < area.Max.X; px++ {
+			var x, y = int32(px) - dst.X, int32(py) - dst.Y
+
+			if 0 != angle {
+				var dx, dy = float64(px) + 0.5 - centreX, float64(py) + 0.5 - centreY
+				var u = (cos * dx) + (sin * dy) + halfWidth
+				var v = (cos * dy) - (sin * dx) + halfHeight
+
+				if u < 0 || v < 0 || u >= float64(dst.W) || v >= float64(dst.H) {
+					continue
+				}
+
+				x, y = int32(u), int32(v)
+			}
+
+			if 0 != flip&FlipHorizontal {
+				x = dst.W - 1 - x
+			}
+
+			if 0 != flip&FlipVertical {
+				y = dst.H - 1 - y
+			}
+
+			var sx, sy = src.X + ((x * src.W) / dst.W), src.Y + ((y * src.H) / dst.H)
+
+			if sx < 0 || sx >= width || sy < 0 || sy >= height {
+				continue
+			}
+
+			var in = from.image.PixOffset(int(sx), int(sy))
+			var out = backend.screen.PixOffset(px, py)
+			var pixel = from.pixel(in)
+
+			if !from.blend {
+				copy(backend.screen.Pix[out:out+3], pixel[:3])
+				backend.screen.Pix[out+3] = 255
+
+				continue
+			}
+
+			// textures hold premultiplied alpha, the same as image.RGBA
+			var alpha = uint32(pixel[3])
+
+			for channel := 0; channel < 3; channel++ {
+				var below = uint32(backend.screen.Pix[out+channel])
+
+				backend.screen.Pix[out+channel] = byte(uint32(pixel[channel]) + ((below * (255 - alpha)) / 255))
+			}
+
+			backend.screen.Pix[out+3] = 255
+		}
+	}
+}
+
+// Present will copy the screen to the frame returned by Frame. When a logical
+// resolution is set, the screen is scaled to fit the frame with black bars
+// along the edges.
+func (backend *SoftwareBackend) Present() {
+	if nil == backend.screen {
+		return
+	}
+
+	var screen, frame = backend.screen.Bounds(), backend.frame.Bounds()
+
+	if screen == frame {
+		copy(backend.frame.Pix, backend.screen.Pix)
+
+		return
+	}
+
+	for i := range backend.frame.Pix {
+		backend.frame.Pix[i] = 0
+	}
+
+	var area = letterbox(screen.Dx(), screen.Dy(), frame.Dx(), frame.Dy(), backend.integer)
+
+	for y := 0; y < area.Dy(); y++ {
+		var sy = (y * screen.Dy()) / area.Dy()
+
+		for x := 0; x < area.Dx(); x++ {
+			var sx = (x * screen.Dx()) / area.Dx()
+			var in = backend.screen.PixOffset(sx, sy)
+			var out = backend.frame.PixOffset(area.Min.X+x, area.Min.Y+y)
+
+			copy(backend.frame.Pix[out:out+4], backend.screen.Pix[in:in+4])
+		}
+	}
+}
+
+// letterbox returns the largest area of the frame that the screen can be
+// scaled to without changing it's aspect ratio, centred in the frame.
+func letterbox(screenWidth, screenHeight, frameWidth, frameHeight int, integer bool) image.Rectangle {
+	var scale = math.Min(
+		float64(frameWidth)/float64(screenWidth),
+		float64(frameHeight)/float64(screenHeight),
+	)
+
+	if integer && scale >= 1 {
+		scale = math.Floor(scale)
+	}
+
+	var width = int(float64(screenWidth) * scale)
+	var height = int(float64(screenHeight) * scale)
+	var x = (frameWidth - width) / 2
+	var y = (frameHeight - height) / 2
+
+	return image.Rect(x, y, x+width, y+height)
+}
+
+// Frame returns the most recently presented frame.
+func (backend *SoftwareBackend) Frame() *image.RGBA {
+	return backend.frame
+}
+
+// Alert will print the message to standard error.
+func (backend *SoftwareBackend) Alert(title, message string) error {
+	_, err := fmt.Fprintf(os.Stderr, "%s: %s\n", title, message)
+
+	return err
+}
+
+func (backend *SoftwareBackend) CreateTexture(width, height int32) (Texture, error) {
+	return &softwareTexture{image.NewRGBA(image.Rect(0, 0, int(width), int(height))), false, nil}, nil
+}
+
+// LoadTexture will decode a PNG, JPEG, or GIF image into a new texture.
+func (backend *SoftwareBackend) LoadTexture(data []byte) (Texture, error) {
+	var decoded, _, err = image.Decode(bytes.NewReader(data))
+
+	if err == image.ErrFormat {
+		return nil, ErrUnsupportedFormat
+	} else if err != nil {
+		return nil, err
+	}
+
+	return backend.UploadTexture(decoded)
+}
+
+func (backend *SoftwareBackend) UploadTexture(decoded image.Image) (Texture, error) {
+	var bounds = decoded.Bounds()
+	var pixels = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+
+	draw.Draw(pixels, pixels.Bounds(), decoded, bounds.Min, draw.Src)
+
+	return &softwareTexture{pixels, true, nil}, nil
+}
+
+func (backend *SoftwareBackend) LoadFont(data []byte, size int) (Font, error) {
+	return nil, ErrUnsupported
+}
+
+func (backend *SoftwareBackend) RenderText(font Font, text string, colour ecs.Colour) (Texture, error) {
+	return nil, ErrUnsupported
+}
+
+func (texture *softwareTexture) Size() (int32, int32) {
+	var bounds = texture.image.Bounds()
+
+	return int32(bounds.Dx()), int32(bounds.Dy())
+}
+
+func (texture *softwareTexture) Update(pixels []byte) error {
+	if len(pixels) < len(texture.image.Pix) {
+		return errors.New("not enough pixels to update texture")
+	}
+
+	copy(texture.image.Pix, pixels)
+
+	return nil
+}
+
+func (texture *softwareTexture) Tint(colour ecs.Colour) {
+	texture.tint = nil
+
+	if colour != White() {
+		texture.tint = &colour
+	}
+}
+
+// pixel returns the colour of the pixel at the offset, multiplied by the tint.
+func (texture *softwareTexture) pixel(offset int) [4]byte {
+	var pixel [4]byte
+
+	copy(pixel[:], texture.image.Pix[offset:offset+4])
+
+	if nil != texture.tint {
+		pixel[0] = byte((uint32(pixel[0]) * uint32(texture.tint.Red)) / 255)
+		pixel[1] = byte((uint32(pixel[1]) * uint32(texture.tint.Green)) / 255)
+		pixel[2] = byte((uint32(pixel[2]) * uint32(texture.tint.Blue)) / 255)
+	}
+
+	return pixel
+}
+
+func (texture *softwareTexture) Destroy() {
+	texture.image = nil
+}
@@ -0,0 +1,356 @@
+package engine
+
+import (
+	"io/fs"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+	"github.com/jordanbrauer/hallucinator/pkg/raster"
+	"github.com/jordanbrauer/hallucinator/pkg/tween"
+)
+
+// instance is the engine that the package level functions act on.
+var instance = CreateEngine(Options{})
+
+// Default returns the engine that the package level functions act on.
+func Default() *Engine {
+	return instance
+}
+
+// Init will initialize the default engine with a window of the given title and
+// size.
+func Init(name string, width, height int32) error {
+	instance.title = name
+	instance.width = width
+	instance.height = height
+
+	return instance.Init()
+}
+
+// World returns the default engine's world.
+func World() ecs.World {
+	return instance.World()
+}
+
+// Input returns the state of the default engine's keyboard and mouse.
+func Input() *InputState {
+	return instance.Input()
+}
+
+// Tweens returns the default engine's tween player.
+func Tweens() *tween.Player {
+	return instance.Tweens()
+}
+
+// Assets returns the default engine's asset manager.
+func Assets() *AssetManager {
+	return instance.Assets()
+}
+
+// Actions returns the default engine's action bindings.
+func Actions() *ActionMap {
+	return instance.Actions()
+}
+
+// Window returns the size and focus of the default engine's window.
+func Window() *WindowState {
+	return instance.Window()
+}
+
+// Resizable sets whether the user can resize the default engine's window.
+func Resizable(enabled bool) {
+	instance.Resizable(enabled)
+}
+
+// Display will switch the default engine's window between windowed,
+// fullscreen, and borderless fullscreen.
+func Display(mode WindowMode) error {
+	return instance.Display(mode)
+}
+
+// DisplayMode returns how the default engine's window is shown on the display.
+func DisplayMode() WindowMode {
+	return instance.DisplayMode()
+}
+
+// Logical sets the resolution that the default engine draws to.
+func Logical(width, height int32) error {
+	return instance.Logical(width, height)
+}
+
+// IntegerScale sets whether the default engine's logical resolution is only
+// ever scaled by whole numbers.
+func IntegerScale(enabled bool) error {
+	return instance.IntegerScale(enabled)
+}
+
+// Resolution returns the size that the default engine draws to.
+func Resolution() (width, height int32) {
+	return instance.Resolution()
+}
+
+// Debug sets the default engine's debug mode to the given boolean.
+func Debug(enabled bool) {
+	instance.Debug(enabled)
+}
+
+// Debugging is used to determine if the default engine is currently running in
+// debug mode.
+func Debugging() bool {
+	return instance.Debugging()
+}
+
+// Use sets the backend that the default engine draws through.
+func Use(platform Backend) {
+	instance.Use(platform)
+}
+
+// Mount sets the file system that the default engine loads assets from.
+func Mount(files fs.FS) {
+	instance.Mount(files)
+}
+
+// Files returns the file system that the default engine loads assets from.
+func Files() fs.FS {
+	return instance.Files()
+}
+
+// Headless sets the default engine to run without a window.
+func Headless(enabled bool) {
+	instance.Headless(enabled)
+}
+
+// IsHeadless is used to determine if the default engine is running without a
+// window.
+func IsHeadless() bool {
+	return instance.IsHeadless()
+}
+
+// FrameLimit caps the number of frames the default engine renders each second.
+func FrameLimit(fps int) {
+	instance.FrameLimit(fps)
+}
+
+// VSync sets whether the default engine waits for the display to refresh.
+func VSync(enabled bool) {
+	instance.VSync(enabled)
+}
+
+// TickRate sets how many times per second the default engine's fixed update
+// closure runs.
+func TickRate(rate float32) {
+	instance.TickRate(rate)
+}
+
+// FramesPerSecond reports the default engine's current framerate.
+func FramesPerSecond() FPS {
+	return instance.FramesPerSecond()
+}
+
+// FrameElapsed is the number of seconds the default engine's last frame took.
+func FrameElapsed() float32 {
+	return instance.FrameElapsed()
+}
+
+// FixedElapsed is the number of seconds simulated by each of the default
+// engine's fixed updates.
+func FixedElapsed() float32 {
+	return instance.FixedElapsed()
+}
+
+// Alpha is how far the default engine's current frame is between fixed updates.
+func Alpha() float32 {
+	return instance.Alpha()
+}
+
+// FixedUpdate will define the default engine's fixed update closure.
+func FixedUpdate(closure Executable) {
+	instance.FixedUpdate(closure)
+}
+
+// Setup will define the default engine's setup closure.
+func Setup(closure Executable) {
+	instance.Setup(closure)
+}
+
+// Teardown will define the default engine's teardown closure.
+func Teardown(closure Executable) {
+	instance.Teardown(closure)
+}
+
+// Run will execute the default engine's main loop with the given closure.
+func Run(update Executable) {
+	instance.Run(update)
+}
+
+// Start will run the default engine's setup closure without entering the main
+// loop.
+func Start() error {
+	return instance.Start()
+}
+
+// Step will run a single frame of the default engine's main loop.
+func Step(update Executable, dt float32) bool {
+	return instance.Step(update, dt)
+}
+
+// Stop will end the default engine's program.
+func Stop() {
+	instance.Stop()
+}
+
+// Running will tell the caller if the default engine is currently running.
+func Running() bool {
+	return instance.Running()
+}
+
+// Render will copy the source area of the texture to the destination area of
+// the default engine's screen.
+func Render(texture Texture, source, dest *Region) {
+	instance.Render(texture, source, dest)
+}
+
+// RenderEx will render the same as Render, turning and flipping the texture.
+func RenderEx(texture Texture, source, dest *Region, angle float32, flip Flip) {
+	instance.RenderEx(texture, source, dest, angle, flip)
+}
+
+// LoadFont will open the font file at the given point size.
+func LoadFont(path string, size int) (Font, error) {
+	return instance.LoadFont(path, size)
+}
+
+// LoadTexture will create a new texture from the given file path.
+func LoadTexture(path string) (Texture, error) {
+	return instance.LoadTexture(path)
+}
+
+// CreateTexture will create a new, empty texture of the given size.
+func CreateTexture(width, height int32) (Texture, error) {
+	return instance.CreateTexture(width, height)
+}
+
+// TexturizeString will render the text with the given font to a new texture.
+func TexturizeString(font Font, text string) (Texture, error) {
+	return instance.TexturizeString(font, text)
+}
+
+// DrawText will draw the text with it's top left corner at the position.
+func DrawText(font Font, text string, x, y int32, colour ecs.Colour) error {
+	return instance.DrawText(font, text, x, y, colour)
+}
+
+// DrawLayout will draw the laid out text with it's top left corner at the
+// position.
+func DrawLayout(layout *TextLayout, x, y int32) error {
+	return instance.DrawLayout(layout, x, y)
+}
+
+// MeasureText returns the size of the text if it were drawn, without drawing
+// it.
+func MeasureText(font Font, text string) (width, height int32) {
+	return instance.MeasureText(font, text)
+}
+
+// Fatal will show the error to the user in a message box and exit the program.
+func Fatal(caught error) {
+	instance.Fatal(caught)
+}
+
+// LoadGamepadMappings will add the gamepad mappings in the file to the default
+// engine's backend.
+func LoadGamepadMappings(path string) error {
+	return instance.LoadGamepadMappings(path)
+}
+
+// AttachVirtualGamepad will plug a gamepad that exists only in software in to
+// the default engine.
+func AttachVirtualGamepad() (VirtualGamepad, error) {
+	return instance.AttachVirtualGamepad()
+}
+
+// Audio returns the default engine's mixer.
+func Audio() Mixer {
+	return instance.Audio()
+}
+
+// OpenAudio will start the default engine's audio device.
+func OpenAudio() error {
+	return instance.OpenAudio()
+}
+
+// LoadSound will decode the sound effect file at the given path.
+func LoadSound(path string) (Sound, error) {
+	return instance.LoadSound(path)
+}
+
+// LoadMusic will open the music file at the given path.
+func LoadMusic(path string) (Music, error) {
+	return instance.LoadMusic(path)
+}
+
+// IsKeyPressed checks if the given key is actively being held or was pressed by
+// the user.
+func IsKeyPressed(key Key) bool {
+	return instance.IsKeyPressed(key)
+}
+
+// Present will copy the default engine's pixels to the screen.
+func Present() error {
+	return instance.Present()
+}
+
+// Buffer returns the default engine's pixels.
+func Buffer() []byte {
+	return instance.Buffer()
+}
+
+// Canvas returns the default engine's in-memory canvas.
+func Canvas() *raster.Canvas {
+	return instance.Canvas()
+}
+
+// Pixels initializes the default engine's pixel texture.
+func Pixels() error {
+	return instance.Pixels()
+}
+
+// Draw will populate the given pixel with the given colour.
+func Draw(x, y int32, colour ecs.Colour) {
+	instance.Draw(x, y, colour)
+}
+
+// Clear will set all pixels to empty (black screen).
+func Clear() {
+	instance.Clear()
+}
+
+// Fill sets the colour to be used for drawing solid shapes.
+func Fill(colour ecs.Colour) {
+	instance.Fill(colour)
+}
+
+// Stroke sets the colour to be used for drawing the outlines of solid shapes.
+func Stroke(colour ecs.Colour) {
+	instance.Stroke(colour)
+}
+
+// Line will plot a single pixel wide line between the given positions.
+func Line(origin, destination ecs.Position) {
+	instance.Line(origin, destination)
+}
+
+// Rect will draw a freeform rectangle of the given size at the given position.
+func Rect(position ecs.Position, dimensions ecs.Dimensions) {
+	instance.Rect(position, dimensions)
+}
+
+// Square will draw a square of the given size to the given position.
+func Square(position ecs.Position, dimensions ecs.Dimensions) {
+	instance.Square(position, dimensions)
+}
+
+// Ellipse will draw a solid ellipse of the given size centred on the given
+// position.
+func Ellipse(position ecs.Position, dimensions ecs.Dimensions) {
+	instance.Ellipse(position, dimensions)
+}
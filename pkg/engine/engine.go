@@ -0,0 +1,263 @@
+package engine
+
+import (
+	"io/fs"
+	"time"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+	"github.com/jordanbrauer/hallucinator/pkg/raster"
+	"github.com/jordanbrauer/hallucinator/pkg/tween"
+)
+
+// DefaultFrameLimit is the number of frames rendered each second when no other
+// limit is given.
+const DefaultFrameLimit = 200
+
+// DefaultTickRate is the number of fixed updates run each second when no other
+// rate is given.
+const DefaultTickRate float32 = 60
+
+// Options configures a new engine. The zero value of every option is a
+// sensible default.
+type Options struct {
+	// Title is shown in the window's title bar.
+	Title string
+
+	// Width and Height are the size of the window in pixels.
+	Width, Height int32
+
+	// Backend is the platform layer to draw through. Defaults to the SDL
+	// backend, or the software backend when headless.
+	Backend Backend
+
+	// Audio opens the audio device when the engine is initialized.
+	Audio bool
+
+	// Mixer is the platform layer to play audio through. Defaults to the SDL
+	// mixer.
+	Mixer Mixer
+
+	// Debug turns on debug mode, counting frames per second.
+	Debug bool
+
+	// Headless runs the engine without a window by using the software backend.
+	Headless bool
+
+	// VSync waits for the display to refresh when presenting each frame.
+	VSync bool
+
+	// Resizable lets the user resize the window.
+	Resizable bool
+
+	// Mode is how the window is shown on the display. Defaults to Windowed.
+	Mode WindowMode
+
+	// LogicalWidth and LogicalHeight are the resolution that is drawn to,
+	// scaled to fit the window. Defaults to the window's size.
+	LogicalWidth, LogicalHeight int32
+
+	// IntegerScale only ever scales the logical resolution by whole numbers.
+	IntegerScale bool
+
+	// FrameLimit caps the number of frames each second. Defaults to
+	// DefaultFrameLimit, and Uncapped removes the limit.
+	FrameLimit int
+
+	// TickRate is the number of fixed updates each second. Defaults to
+	// DefaultTickRate.
+	TickRate float32
+
+	// Files is the file system that assets are loaded from. Defaults to
+	// SystemFiles.
+	Files fs.FS
+}
+
+// Engine owns a window, a world, and the main loop that drives them. Most
+// programs only need one, and can use the package level functions which act on
+// a default engine, but any number of engines can be created and run.
+type Engine struct {
+	title         string
+	width, height int32
+	backend       Backend
+	mixer         Mixer
+	audio         *AudioSystem
+	openAudio     bool
+	files         fs.FS
+	atlases       map[Font]*glyphAtlas
+	tweens        *tween.Player
+	world         ecs.World
+	input         *InputState
+	actions       *ActionMap
+	assets        *AssetManager
+	window        *WindowState
+	debug         bool
+	headless      bool
+	opened        bool
+	running       bool
+	vsync         bool
+
+	resizable     bool
+	mode          WindowMode
+	logicalWidth  int32
+	logicalHeight int32
+	integer       bool
+
+	setup    Executable
+	teardown Executable
+	fixed    Executable
+
+	tickRate    float32
+	accumulator float32
+	alpha       float32
+
+	frameLimit   int
+	frameStart   time.Time
+	frameElapsed float32
+	epoch        time.Time
+	fpsLast      uint32
+	fpsCurrent   int
+	fpsFrames    int
+	fpsStats     FPS
+	frameTimes   []float32
+
+	canvas  *raster.Canvas
+	texture Texture
+}
+
+// CreateEngine returns a new engine configured by the given options. The window
+// is not opened until Init is called.
+func CreateEngine(options Options) *Engine {
+	var engine = new(Engine)
+	engine.title = options.Title
+	engine.width = options.Width
+	engine.height = options.Height
+	engine.debug = options.Debug
+	engine.vsync = options.VSync
+	engine.mixer = options.Mixer
+	engine.openAudio = options.Audio
+	engine.resizable = options.Resizable
+	engine.mode = options.Mode
+	engine.logicalWidth = options.LogicalWidth
+	engine.logicalHeight = options.LogicalHeight
+	engine.integer = options.IntegerScale
+	engine.setup = defaultWorldExecutable
+	engine.teardown = defaultWorldExecutable
+	engine.tickRate = options.TickRate
+	engine.frameLimit = options.FrameLimit
+	engine.epoch = time.Now()
+	engine.canvas = raster.CreateCanvas(0, 0)
+	engine.input = CreateInputState()
+	engine.actions = CreateActionMap(engine.input)
+	engine.assets = CreateAssetManager(engine)
+	engine.atlases = make(map[Font]*glyphAtlas)
+	engine.tweens = tween.CreatePlayer()
+	engine.window = CreateWindowState(options.Width, options.Height)
+
+	engine.Mount(options.Files)
+
+	if nil == engine.mixer {
+		engine.mixer = CreateSDLMixer()
+	}
+
+	if 0 == engine.tickRate {
+		engine.tickRate = DefaultTickRate
+	}
+
+	if 0 == engine.frameLimit {
+		engine.frameLimit = DefaultFrameLimit
+	}
+
+	engine.Headless(options.Headless)
+
+	if nil != options.Backend {
+		engine.Use(options.Backend)
+	}
+
+	return engine
+}
+
+// World returns the engine's world, which is created by Init.
+func (engine *Engine) World() ecs.World {
+	return engine.world
+}
+
+// Backend returns the platform layer that the engine draws through.
+func (engine *Engine) Backend() Backend {
+	return engine.backend
+}
+
+// Input returns the state of the keyboard and mouse for the current frame.
+func (engine *Engine) Input() *InputState {
+	return engine.input
+}
+
+// Assets returns the engine's asset manager.
+func (engine *Engine) Assets() *AssetManager {
+	return engine.assets
+}
+
+// Tweens returns the player that updates the engine's tweens each frame.
+func (engine *Engine) Tweens() *tween.Player {
+	return engine.tweens
+}
+
+// Actions returns the engine's action bindings.
+func (engine *Engine) Actions() *ActionMap {
+	return engine.actions
+}
+
+// Debug sets the engine's debug mode to the given boolean.
+func (engine *Engine) Debug(enabled bool) {
+	engine.debug = enabled
+}
+
+// Debugging is used to determine if the engine is currently running in debug
+// mode.
+func (engine *Engine) Debugging() bool {
+	return engine.debug
+}
+
+// Use sets the backend that the engine opens it's window and draws through. It
+// must be called before Init.
+func (engine *Engine) Use(platform Backend) {
+	engine.backend = platform
+	engine.headless = false
+}
+
+// Headless sets the engine to run without a window, for use on servers and in
+// tests, by drawing through the software backend. It must be called before
+// Init.
+func (engine *Engine) Headless(enabled bool) {
+	if enabled {
+		engine.backend = CreateSoftwareBackend()
+	} else {
+		engine.backend = CreateSDLBackend()
+	}
+
+	engine.headless = enabled
+}
+
+// IsHeadless is used to determine if the engine is running without a window.
+func (engine *Engine) IsHeadless() bool {
+	return engine.headless
+}
+
+// FrameLimit caps the number of frames rendered each second, sleeping away the
+// remainder of any frame that finishes early. Pass Uncapped to render as fast
+// as possible, such as when relying on VSync. The default is 200.
+func (engine *Engine) FrameLimit(fps int) {
+	engine.frameLimit = fps
+}
+
+// VSync sets whether presenting each frame waits for the display to refresh,
+// which prevents tearing and caps the framerate to the display's refresh rate.
+// It must be called before Init.
+func (engine *Engine) VSync(enabled bool) {
+	engine.vsync = enabled
+}
+
+// TickRate sets how many times per second the fixed update closure runs. The
+// default is 60.
+func (engine *Engine) TickRate(rate float32) {
+	engine.tickRate = rate
+}
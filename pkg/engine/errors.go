@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// ErrMissingAsset is returned when an asset file does not exist.
+var ErrMissingAsset = errors.New("asset not found")
+
+// ErrUnsupportedFormat is returned when an asset file is not in a format that
+// the backend is able to decode.
+var ErrUnsupportedFormat = errors.New("unsupported asset format")
+
+// ErrNotInitialized is returned when the engine is used before Init has opened
+// the backend, or after it has stopped.
+var ErrNotInitialized = errors.New("engine is not initialized")
+
+// AssetError records the asset that failed to load, and why. Use errors.Is to
+// check for common causes, such as ErrMissingAsset.
+type AssetError struct {
+	Kind string
+	Path string
+	Err  error
+}
+
+func (err *AssetError) Error() string {
+	return fmt.Sprintf("unable to load %s %s: %s", err.Kind, err.Path, err.Err)
+}
+
+func (err *AssetError) Unwrap() error {
+	return err.Err
+}
+
+// Fatal will show the error to the user in a message box and exit the program.
+// It does nothing for a nil error, and can be used to opt back in to exiting
+// when an asset fails to load.
+func (engine *Engine) Fatal(caught error) {
+	if nil == caught {
+		return
+	}
+
+	if err := engine.backend.Alert("Error", caught.Error()); err != nil {
+		fmt.Fprintln(os.Stderr, caught)
+	}
+
+	os.Exit(1)
+}
+
+// assetError will wrap the error from loading an asset of the given kind,
+// replacing a missing file error with ErrMissingAsset.
+func assetError(kind, path string, err error) error {
+	if nil == err {
+		return nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		err = ErrMissingAsset
+	}
+
+	return &AssetError{kind, path, err}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// SystemFiles is the operating system's file system, where paths are relative
+// to the working directory. It is where assets are loaded from unless another
+// file system is mounted.
+var SystemFiles fs.FS = systemFiles{}
+
+type systemFiles struct{}
+
+func (systemFiles) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (systemFiles) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// Overlay returns a file system that opens each file from the first of the
+// layers that has it, such as a directory of mods on top of the assets
+// embedded in the game.
+func Overlay(layers ...fs.FS) fs.FS {
+	return overlay(layers)
+}
+
+type overlay []fs.FS
+
+func (layers overlay) Open(name string) (fs.File, error) {
+	var err error = &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+
+	for _, layer := range layers {
+		var file fs.File
+
+		if file, err = layer.Open(name); nil == err || !errors.Is(err, fs.ErrNotExist) {
+			return file, err
+		}
+	}
+
+	return nil, err
+}
+
+// Mount sets the file system that assets are loaded from, such as an embed.FS
+// bundled into the program or a zip archive. Paths are always written with
+// forward slashes, and a leading "./" is ignored.
+func (engine *Engine) Mount(files fs.FS) {
+	if nil == files {
+		files = SystemFiles
+	}
+
+	engine.files = files
+}
+
+// Files returns the file system that assets are loaded from.
+func (engine *Engine) Files() fs.FS {
+	return engine.files
+}
+
+// cleanPath returns the name of an asset file as it is read, so that different
+// ways of writing the same path, such as "./a.png" and "a.png", are the same.
+func cleanPath(name string) string {
+	return path.Clean(filepath.ToSlash(name))
+}
+
+// read will return the contents of the asset file of the given kind. It is safe
+// to call from any goroutine.
+func (engine *Engine) read(kind, name string) ([]byte, error) {
+	var data, err = fs.ReadFile(engine.files, cleanPath(name))
+
+	if err != nil {
+		return nil, assetError(kind, name, err)
+	}
+
+	return data, nil
+}
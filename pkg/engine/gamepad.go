@@ -0,0 +1,319 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+)
+
+// GamepadButton is a button on a gamepad, laid out like an Xbox controller.
+type GamepadButton int
+
+// Gamepad buttons available to check with a gamepad's state.
+const (
+	GamepadA GamepadButton = iota
+	GamepadB
+	GamepadX
+	GamepadY
+	GamepadBack
+	GamepadGuide
+	GamepadStart
+	GamepadLeftStick
+	GamepadRightStick
+	GamepadLeftShoulder
+	GamepadRightShoulder
+	GamepadDPadUp
+	GamepadDPadDown
+	GamepadDPadLeft
+	GamepadDPadRight
+
+	// GamepadButtonCount is the number of gamepad buttons that are tracked.
+	GamepadButtonCount = 32
+)
+
+// GamepadAxis is an analog stick axis or trigger on a gamepad.
+type GamepadAxis int
+
+// Gamepad axes available to check with a gamepad's state. Sticks range from -1
+// to 1, with negative values being up and to the left, and triggers range from
+// 0 to 1.
+const (
+	GamepadLeftX GamepadAxis = iota
+	GamepadLeftY
+	GamepadRightX
+	GamepadRightY
+	GamepadLeftTrigger
+	GamepadRightTrigger
+
+	// GamepadAxisCount is the number of gamepad axes that are tracked.
+	GamepadAxisCount = 6
+)
+
+var gamepadButtonNames = map[GamepadButton]string{
+	GamepadA:             "A",
+	GamepadB:             "B",
+	GamepadX:             "X",
+	GamepadY:             "Y",
+	GamepadBack:          "Back",
+	GamepadGuide:         "Guide",
+	GamepadStart:         "Start",
+	GamepadLeftStick:     "LeftStick",
+	GamepadRightStick:    "RightStick",
+	GamepadLeftShoulder:  "LeftShoulder",
+	GamepadRightShoulder: "RightShoulder",
+	GamepadDPadUp:        "DPadUp",
+	GamepadDPadDown:      "DPadDown",
+	GamepadDPadLeft:      "DPadLeft",
+	GamepadDPadRight:     "DPadRight",
+}
+
+var gamepadAxisNames = map[GamepadAxis]string{
+	GamepadLeftX:        "LeftX",
+	GamepadLeftY:        "LeftY",
+	GamepadRightX:       "RightX",
+	GamepadRightY:       "RightY",
+	GamepadLeftTrigger:  "LeftTrigger",
+	GamepadRightTrigger: "RightTrigger",
+}
+
+// String returns the gamepad button's name, such as "A" or "DPadUp".
+func (button GamepadButton) String() string {
+	if name, ok := gamepadButtonNames[button]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("GamepadButton(%d)", int(button))
+}
+
+// String returns the gamepad axis's name, such as "LeftX".
+func (axis GamepadAxis) String() string {
+	if name, ok := gamepadAxisNames[axis]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("GamepadAxis(%d)", int(axis))
+}
+
+// DefaultDeadZone is how far a gamepad's axes must be pushed before they
+// register, to hide the drift of worn sticks.
+const DefaultDeadZone float32 = 0.15
+
+// GamepadConnected is emitted when a gamepad is plugged in.
+type GamepadConnected struct {
+	Gamepad *Gamepad
+}
+
+// Name of the event.
+func (GamepadConnected) Name() string {
+	return "gamepad_connected"
+}
+
+// GamepadDisconnected is emitted when a gamepad is unplugged.
+type GamepadDisconnected struct {
+	ID int
+}
+
+// Name of the event.
+func (GamepadDisconnected) Name() string {
+	return "gamepad_disconnected"
+}
+
+// VirtualGamepad is a gamepad that exists only in software, for testing
+// gamepad support without any hardware. It is connected like any other
+// gamepad the next time events are polled, and what it presses and moves
+// arrives the same way.
+type VirtualGamepad interface {
+	// Press will hold the button down.
+	Press(button GamepadButton) error
+
+	// Release will let the button back up.
+	Release(button GamepadButton) error
+
+	// Move will push the axis to the given value.
+	Move(axis GamepadAxis, value float32) error
+
+	// Detach will unplug the gamepad.
+	Detach() error
+}
+
+// Gamepad is the state of a single connected gamepad for the current frame.
+type Gamepad struct {
+	id   int
+	name string
+
+	buttons     [GamepadButtonCount]bool
+	buttonsDown [GamepadButtonCount]bool
+	buttonsUp   [GamepadButtonCount]bool
+	axes        [GamepadAxisCount]float32
+	deadZone    float32
+}
+
+// ID identifies the gamepad for as long as it stays connected.
+func (gamepad *Gamepad) ID() int {
+	return gamepad.id
+}
+
+// Name is the gamepad's product name, if known.
+func (gamepad *Gamepad) Name() string {
+	return gamepad.name
+}
+
+// Held tells if the button is currently held down.
+func (gamepad *Gamepad) Held(button GamepadButton) bool {
+	return validGamepadButton(button) && gamepad.buttons[button]
+}
+
+// JustPressed tells if the button was pressed since the previous frame.
+func (gamepad *Gamepad) JustPressed(button GamepadButton) bool {
+	return validGamepadButton(button) && gamepad.buttonsDown[button]
+}
+
+// JustReleased tells if the button was released since the previous frame.
+func (gamepad *Gamepad) JustReleased(button GamepadButton) bool {
+	return validGamepadButton(button) && gamepad.buttonsUp[button]
+}
+
+// Axis returns the current value of the axis. Values within the dead zone are
+// zero, and the rest are rescaled so that the axis still moves smoothly from
+// the edge of the dead zone.
+func (gamepad *Gamepad) Axis(axis GamepadAxis) float32 {
+	if !validGamepadAxis(axis) {
+		return 0
+	}
+
+	var value = gamepad.axes[axis]
+	var magnitude = maths.Abs(value)
+
+	if magnitude <= gamepad.deadZone {
+		return 0
+	}
+
+	var scaled = maths.Min((magnitude-gamepad.deadZone)/(1-gamepad.deadZone), 1)
+
+	if value < 0 {
+		return -scaled
+	}
+
+	return scaled
+}
+
+// SetDeadZone sets how far the gamepad's axes must be pushed before they
+// register, from zero to one.
+func (gamepad *Gamepad) SetDeadZone(zone float32) {
+	gamepad.deadZone = maths.Clamp(zone, 0, 0.99)
+}
+
+// Press records that the button went down.
+func (gamepad *Gamepad) Press(button GamepadButton) {
+	if !validGamepadButton(button) || gamepad.buttons[button] {
+		return
+	}
+
+	gamepad.buttons[button] = true
+	gamepad.buttonsDown[button] = true
+}
+
+// Release records that the button came back up.
+func (gamepad *Gamepad) Release(button GamepadButton) {
+	if !validGamepadButton(button) || !gamepad.buttons[button] {
+		return
+	}
+
+	gamepad.buttons[button] = false
+	gamepad.buttonsUp[button] = true
+}
+
+// Move records the new value of the axis.
+func (gamepad *Gamepad) Move(axis GamepadAxis, value float32) {
+	if !validGamepadAxis(axis) {
+		return
+	}
+
+	gamepad.axes[axis] = value
+}
+
+// advance will forget the buttons pressed and released during the frame.
+func (gamepad *Gamepad) advance() {
+	gamepad.buttonsDown = [GamepadButtonCount]bool{}
+	gamepad.buttonsUp = [GamepadButtonCount]bool{}
+}
+
+// ConnectGamepad records that a gamepad with the given ID was connected,
+// returning it's state. Connecting an ID twice returns the existing state.
+func (input *InputState) ConnectGamepad(id int, name string) *Gamepad {
+	if gamepad, ok := input.gamepads[id]; ok {
+		return gamepad
+	}
+
+	if nil == input.gamepads {
+		input.gamepads = make(map[int]*Gamepad)
+	}
+
+	var gamepad = &Gamepad{id: id, name: name}
+	gamepad.SetDeadZone(input.deadZone)
+	input.gamepads[id] = gamepad
+	input.connected = append(input.connected, gamepad)
+
+	return gamepad
+}
+
+// DisconnectGamepad records that the gamepad with the given ID was removed.
+func (input *InputState) DisconnectGamepad(id int) {
+	if _, ok := input.gamepads[id]; !ok {
+		return
+	}
+
+	delete(input.gamepads, id)
+	input.disconnected = append(input.disconnected, id)
+}
+
+// JustConnected returns the gamepads connected since the previous frame.
+func (input *InputState) JustConnected() []*Gamepad {
+	return input.connected
+}
+
+// JustDisconnected returns the IDs of the gamepads disconnected since the
+// previous frame.
+func (input *InputState) JustDisconnected() []int {
+	return input.disconnected
+}
+
+// SetDeadZone sets the dead zone of every gamepad, including those connected
+// later.
+func (input *InputState) SetDeadZone(zone float32) {
+	input.deadZone = zone
+
+	for _, gamepad := range input.gamepads {
+		gamepad.SetDeadZone(zone)
+	}
+}
+
+// Gamepad returns the state of the gamepad with the given ID, or nil if it is
+// not connected.
+func (input *InputState) Gamepad(id int) *Gamepad {
+	return input.gamepads[id]
+}
+
+// Gamepads returns every connected gamepad, ordered by ID.
+func (input *InputState) Gamepads() []*Gamepad {
+	var gamepads = make([]*Gamepad, 0, len(input.gamepads))
+
+	for _, gamepad := range input.gamepads {
+		gamepads = append(gamepads, gamepad)
+	}
+
+	sort.Slice(gamepads, func(i, j int) bool {
+		return gamepads[i].id < gamepads[j].id
+	})
+
+	return gamepads
+}
+
+func validGamepadButton(button GamepadButton) bool {
+	return button >= 0 && button < GamepadButtonCount
+}
+
+func validGamepadAxis(axis GamepadAxis) bool {
+	return axis >= 0 && axis < GamepadAxisCount
+}
@@ -2,103 +2,120 @@ package engine
 
 import (
 	"github.com/jordanbrauer/hallucinator/pkg/ecs"
-	"github.com/veandco/go-sdl2/sdl"
+	"github.com/jordanbrauer/hallucinator/pkg/raster"
 )
 
-var fillColour = White()
-var strokeColour = White()
-var texture *sdl.Texture
-var pixels []byte
+// Present will copy the pixels to the screen. ErrNotInitialized is returned if
+// Init has not opened the backend.
+func (engine *Engine) Present() error {
+	if !engine.opened {
+		return ErrNotInitialized
+	}
+
+	if err := engine.fitTexture(); err != nil {
+		return err
+	}
+
+	if err := engine.texture.Update(engine.canvas.Pixels()); err != nil {
+		return err
+	}
 
-func Present() {
-	texture.Update(nil, pixels, (int(windowWidth) * 4))
-	Render(texture, nil, nil)
+	engine.Render(engine.texture, nil, nil)
+
+	return nil
+}
+
+// Buffer returns the pixels drawn to since the last clear, four bytes per pixel
+// in RGBA order, row by row from the top left of the window.
+func (engine *Engine) Buffer() []byte {
+	return engine.canvas.Pixels()
+}
+
+// Canvas returns the in-memory canvas that the pixel drawing functions draw
+// to, such as for saving the current frame as an image.
+func (engine *Engine) Canvas() *raster.Canvas {
+	return engine.canvas
 }
 
 // Draw will populate the given pixel in a set of pixels with the given colour.
-func Draw(x, y int32, colour ecs.Colour) {
-	var index = ((y * windowWidth) + x) * 4
-	var bit = int32(len(pixels) - 4)
-
-	if index < bit && index >= 0 {
-		pixels[index] = colour.Red
-		pixels[(index + 1)] = colour.Green
-		pixels[(index + 2)] = colour.Blue
-	}
+func (engine *Engine) Draw(x, y int32, colour ecs.Colour) {
+	engine.canvas.Draw(x, y, colour)
 }
 
 // Pixels initializes a new texture to be drawn to using pure pixels and various
 // helper methods such as `Square`, `Rect`, `Line`, `Clear`, etc.
-func Pixels() {
-	pixels = make([]byte, (windowWidth * windowHeight * 4))
-	texture = CreateTexture(windowWidth, windowHeight)
+//
+// The pixels are sized to the resolution, and follow it as the window is
+// resized or a logical resolution is set.
+func (engine *Engine) Pixels() error {
+	engine.canvas.Resize(engine.Resolution())
+
+	return engine.fitTexture()
+}
+
+// fitTexture will replace the texture that pixels are copied to when it is not
+// the same size as the canvas.
+func (engine *Engine) fitTexture() error {
+	var width, height = engine.canvas.Size()
+
+	if nil != engine.texture {
+		if textureWidth, textureHeight := engine.texture.Size(); textureWidth == width && textureHeight == height {
+			return nil
+		}
+	}
+
+	var texture, err = engine.CreateTexture(width, height)
+
+	if err != nil {
+		return err
+	}
+
+	if nil != engine.texture {
+		engine.texture.Destroy()
+	}
+
+	engine.texture = texture
+
+	return nil
 }
 
 // Clear will set all pixels in a given set of pixels to empty (black screen),
 // iterating through in order that they are stored in memory.
-func Clear() {
-	for i := range pixels {
-		pixels[i] = 0
-	}
+func (engine *Engine) Clear() {
+	engine.canvas.Clear()
 }
 
 // Fill sets the colour to be used for drawing solid shapes.
-func Fill(colour ecs.Colour) {
-	fillColour = colour
+func (engine *Engine) Fill(colour ecs.Colour) {
+	engine.canvas.Fill(colour)
 }
 
 // Stroke sets the colour to be used for drawing the outlines of solid shapes.
-func Stroke(colour ecs.Colour) {
-	strokeColour = colour
+func (engine *Engine) Stroke(colour ecs.Colour) {
+	engine.canvas.Stroke(colour)
 }
 
 // Line will plot a single pixel wide line from an x, y origin to an x, y
 // destination.
-func Line(origin, destination ecs.Position) {
-	var xOrigin = int(origin.X)
-	var yOrigin = int(origin.Y)
-	var xDestination = int(destination.X)
-	var yDestination = int(destination.Y)
-	var dx = xDestination - xOrigin
-	var dy = yDestination - yOrigin
-
-	if xOrigin > xDestination {
-		for x := xOrigin; x > xDestination; x-- {
-			y := yOrigin - dy*(x-xOrigin)/dx
-
-			Draw(int32(x), int32(y), strokeColour)
-		}
-	} else if xOrigin < xDestination {
-		for x := xOrigin; x < xDestination; x++ {
-			y := yOrigin + dy*(x-xOrigin)/dx
-
-			Draw(int32(x), int32(y), strokeColour)
-		}
-	}
+func (engine *Engine) Line(origin, destination ecs.Position) {
+	engine.canvas.Line(origin, destination)
 }
 
 // Rect will draw a freeform rectangle of the given size at the given x, y
 // coordinates.
 //
 // Drawing of the rectangle will begin the top left corner of the rectangle.
-func Rect(position ecs.Position, dimensions ecs.Dimensions) {
-	var width = int(dimensions.Width)
-	var height = int(dimensions.Height)
-	var xOrigin = int(position.X)
-	var yOrigin = int(position.Y)
-
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			Draw(int32((xOrigin + x)), int32((yOrigin + y)), fillColour)
-		}
-	}
+func (engine *Engine) Rect(position ecs.Position, dimensions ecs.Dimensions) {
+	engine.canvas.Rect(position, dimensions)
 }
 
 // Square will draw a square of the given size to the given position.
-func Square(position ecs.Position, dimensions ecs.Dimensions) {
-	Rect(position, dimensions)
+func (engine *Engine) Square(position ecs.Position, dimensions ecs.Dimensions) {
+	engine.canvas.Square(position, dimensions)
 }
 
-// func Ellipse(xOrigin, yOrigin, width, height int, colour ecs.Colour) {
-
-// }
+// Ellipse will draw a solid ellipse of the given size centred on the given
+// position.
+func (engine *Engine) Ellipse(position ecs.Position, dimensions ecs.Dimensions) {
+	engine.canvas.Ellipse(position, dimensions)
+}
@@ -0,0 +1,233 @@
+package engine
+
+import "fmt"
+
+// MouseButton is a button on the mouse.
+type MouseButton int
+
+// Mouse buttons available to check with the input state.
+const (
+	MouseLeft MouseButton = iota + 1
+	MouseMiddle
+	MouseRight
+	MouseX1
+	MouseX2
+
+	// MouseButtonCount is the number of mouse buttons that are tracked.
+	MouseButtonCount = 8
+)
+
+var mouseButtonNames = map[MouseButton]string{
+	MouseLeft:   "Left",
+	MouseMiddle: "Middle",
+	MouseRight:  "Right",
+	MouseX1:     "X1",
+	MouseX2:     "X2",
+}
+
+// String returns the mouse button's name, such as "Left".
+func (button MouseButton) String() string {
+	if name, ok := mouseButtonNames[button]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("MouseButton(%d)", int(button))
+}
+
+// InputState is a snapshot of the keyboard and mouse for the current frame. As
+// well as what is being held, it tracks what was pressed and released since
+// the previous frame, so that "on press" logic does not need to remember
+// anything itself.
+//
+// It is added to every world as a resource named "input", and the engine's
+// backend fills it in as events arrive.
+type InputState struct {
+	keys     [KeyCount]bool
+	keysDown [KeyCount]bool
+	keysUp   [KeyCount]bool
+
+	buttons     [MouseButtonCount]bool
+	buttonsDown [MouseButtonCount]bool
+	buttonsUp   [MouseButtonCount]bool
+
+	mouseX, mouseY   int32
+	motionX, motionY int32
+	wheelX, wheelY   int32
+	text             string
+
+	gamepads     map[int]*Gamepad
+	connected    []*Gamepad
+	disconnected []int
+	deadZone     float32
+}
+
+// CreateInputState returns an input state with nothing held.
+func CreateInputState() *InputState {
+	return &InputState{deadZone: DefaultDeadZone}
+}
+
+// Name of the input resource.
+func (input *InputState) Name() string {
+	return "input"
+}
+
+// Held tells if the key is currently held down.
+func (input *InputState) Held(key Key) bool {
+	return validKey(key) && input.keys[key]
+}
+
+// JustPressed tells if the key was pressed since the previous frame.
+func (input *InputState) JustPressed(key Key) bool {
+	return validKey(key) && input.keysDown[key]
+}
+
+// JustReleased tells if the key was released since the previous frame.
+func (input *InputState) JustReleased(key Key) bool {
+	return validKey(key) && input.keysUp[key]
+}
+
+// ButtonHeld tells if the mouse button is currently held down.
+func (input *InputState) ButtonHeld(button MouseButton) bool {
+	return validButton(button) && input.buttons[button]
+}
+
+// ButtonJustPressed tells if the mouse button was pressed since the previous
+// frame.
+func (input *InputState) ButtonJustPressed(button MouseButton) bool {
+	return validButton(button) && input.buttonsDown[button]
+}
+
+// ButtonJustReleased tells if the mouse button was released since the previous
+// frame.
+func (input *InputState) ButtonJustReleased(button MouseButton) bool {
+	return validButton(button) && input.buttonsUp[button]
+}
+
+// Mouse returns the position of the mouse cursor, in pixels from the top left
+// of the window.
+func (input *InputState) Mouse() (x, y int32) {
+	return input.mouseX, input.mouseY
+}
+
+// Motion returns how far the mouse moved since the previous frame.
+func (input *InputState) Motion() (x, y int32) {
+	return input.motionX, input.motionY
+}
+
+// Wheel returns how far the mouse wheel scrolled since the previous frame.
+// Positive values are away from the user and to the right.
+func (input *InputState) Wheel() (x, y int32) {
+	return input.wheelX, input.wheelY
+}
+
+// Text returns the text typed since the previous frame, after the operating
+// system has applied the keyboard layout, dead keys, and input methods.
+func (input *InputState) Text() string {
+	return input.text
+}
+
+// Press records that the key went down. Backends call it as events arrive, and
+// tests can call it to simulate the user.
+func (input *InputState) Press(key Key) {
+	if !validKey(key) || input.keys[key] {
+		return
+	}
+
+	input.keys[key] = true
+	input.keysDown[key] = true
+}
+
+// Release records that the key came back up.
+func (input *InputState) Release(key Key) {
+	if !validKey(key) || !input.keys[key] {
+		return
+	}
+
+	input.keys[key] = false
+	input.keysUp[key] = true
+}
+
+// PressButton records that the mouse button went down.
+func (input *InputState) PressButton(button MouseButton) {
+	if !validButton(button) || input.buttons[button] {
+		return
+	}
+
+	input.buttons[button] = true
+	input.buttonsDown[button] = true
+}
+
+// ReleaseButton records that the mouse button came back up.
+func (input *InputState) ReleaseButton(button MouseButton) {
+	if !validButton(button) || !input.buttons[button] {
+		return
+	}
+
+	input.buttons[button] = false
+	input.buttonsUp[button] = true
+}
+
+// MoveMouse records that the mouse moved to the given position, by the given
+// relative amount.
+func (input *InputState) MoveMouse(x, y, dx, dy int32) {
+	input.mouseX = x
+	input.mouseY = y
+	input.motionX += dx
+	input.motionY += dy
+}
+
+// Scroll records that the mouse wheel scrolled by the given amount.
+func (input *InputState) Scroll(x, y int32) {
+	input.wheelX += x
+	input.wheelY += y
+}
+
+// Type records that the user entered the given text.
+func (input *InputState) Type(text string) {
+	input.text += text
+}
+
+// ReleaseAll will release every key and button that is held, such as when the
+// window loses focus and would otherwise never hear of them coming up.
+func (input *InputState) ReleaseAll() {
+	for key := range input.keys {
+		input.Release(Key(key))
+	}
+
+	for button := range input.buttons {
+		input.ReleaseButton(MouseButton(button))
+	}
+
+	for _, gamepad := range input.gamepads {
+		for button := range gamepad.buttons {
+			gamepad.Release(GamepadButton(button))
+		}
+	}
+}
+
+// advance will forget everything that happened during the frame, keeping only
+// what is held and where the mouse is.
+func (input *InputState) advance() {
+	input.keysDown = [KeyCount]bool{}
+	input.keysUp = [KeyCount]bool{}
+	input.buttonsDown = [MouseButtonCount]bool{}
+	input.buttonsUp = [MouseButtonCount]bool{}
+	input.motionX, input.motionY = 0, 0
+	input.wheelX, input.wheelY = 0, 0
+	input.text = ""
+
+	input.connected = nil
+	input.disconnected = nil
+
+	for _, gamepad := range input.gamepads {
+		gamepad.advance()
+	}
+}
+
+func validKey(key Key) bool {
+	return key >= 0 && key < KeyCount
+}
+
+func validButton(button MouseButton) bool {
+	return button >= 0 && button < MouseButtonCount
+}
@@ -0,0 +1,168 @@
+package engine
+
+import "fmt"
+
+// Key is a physical key on the keyboard, identified by it's USB HID usage ID
+// so that it is the same on every backend and keyboard layout.
+type Key int
+
+// Keys available to check with IsKeyPressed.
+const (
+	KeyA Key = iota + 4
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+	Key1
+	Key2
+	Key3
+	Key4
+	Key5
+	Key6
+	Key7
+	Key8
+	Key9
+	Key0
+	KeyReturn
+	KeyEscape
+	KeyBackspace
+	KeyTab
+	KeySpace
+)
+
+// Function keys.
+const (
+	KeyF1 Key = iota + 58
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+// Arrow keys.
+const (
+	KeyRight Key = iota + 79
+	KeyLeft
+	KeyDown
+	KeyUp
+)
+
+// Modifier keys.
+const (
+	KeyLeftControl Key = iota + 224
+	KeyLeftShift
+	KeyLeftAlt
+	KeyLeftSuper
+	KeyRightControl
+	KeyRightShift
+	KeyRightAlt
+	KeyRightSuper
+)
+
+// KeyCount is one more than the highest key ID, for sizing keyboard state.
+const KeyCount = 512
+
+var keyNames = map[Key]string{
+	KeyA:            "A",
+	KeyB:            "B",
+	KeyC:            "C",
+	KeyD:            "D",
+	KeyE:            "E",
+	KeyF:            "F",
+	KeyG:            "G",
+	KeyH:            "H",
+	KeyI:            "I",
+	KeyJ:            "J",
+	KeyK:            "K",
+	KeyL:            "L",
+	KeyM:            "M",
+	KeyN:            "N",
+	KeyO:            "O",
+	KeyP:            "P",
+	KeyQ:            "Q",
+	KeyR:            "R",
+	KeyS:            "S",
+	KeyT:            "T",
+	KeyU:            "U",
+	KeyV:            "V",
+	KeyW:            "W",
+	KeyX:            "X",
+	KeyY:            "Y",
+	KeyZ:            "Z",
+	Key1:            "1",
+	Key2:            "2",
+	Key3:            "3",
+	Key4:            "4",
+	Key5:            "5",
+	Key6:            "6",
+	Key7:            "7",
+	Key8:            "8",
+	Key9:            "9",
+	Key0:            "0",
+	KeyReturn:       "Return",
+	KeyEscape:       "Escape",
+	KeyBackspace:    "Backspace",
+	KeyTab:          "Tab",
+	KeySpace:        "Space",
+	KeyF1:           "F1",
+	KeyF2:           "F2",
+	KeyF3:           "F3",
+	KeyF4:           "F4",
+	KeyF5:           "F5",
+	KeyF6:           "F6",
+	KeyF7:           "F7",
+	KeyF8:           "F8",
+	KeyF9:           "F9",
+	KeyF10:          "F10",
+	KeyF11:          "F11",
+	KeyF12:          "F12",
+	KeyRight:        "Right",
+	KeyLeft:         "Left",
+	KeyDown:         "Down",
+	KeyUp:           "Up",
+	KeyLeftControl:  "LeftControl",
+	KeyLeftShift:    "LeftShift",
+	KeyLeftAlt:      "LeftAlt",
+	KeyLeftSuper:    "LeftSuper",
+	KeyRightControl: "RightControl",
+	KeyRightShift:   "RightShift",
+	KeyRightAlt:     "RightAlt",
+	KeyRightSuper:   "RightSuper",
+}
+
+// String returns the key's name, such as "A", "Space", or "LeftShift".
+func (key Key) String() string {
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Key(%d)", int(key))
+}
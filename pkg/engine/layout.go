@@ -0,0 +1,417 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// Alignment is how each line of text is placed within the width it is laid out
+// in.
+type Alignment int
+
+// Alignments available to text layouts.
+const (
+	AlignLeft Alignment = iota
+	AlignCentre
+	AlignRight
+)
+
+// DefaultTabSize is the number of spaces between tab stops when no other size
+// is given.
+const DefaultTabSize = 4
+
+// TextStyle describes how text is laid out.
+type TextStyle struct {
+	// Font is used for all text that is not bold or italic.
+	Font Font
+
+	// Bold, Italic, and BoldItalic are switched to by markup. Each defaults to
+	// the closest font that is given, and finally to Font.
+	Bold, Italic, BoldItalic Font
+
+	// Colour of all text that is not coloured by markup.
+	Colour ecs.Colour
+
+	// Width that lines are wrapped to, in pixels. Zero never wraps, so lines
+	// only end at newlines.
+	Width int32
+
+	// Align places each line within the width, or within the widest line when
+	// the text is not wrapped.
+	Align Alignment
+
+	// TabSize is the number of spaces between tab stops. Defaults to
+	// DefaultTabSize.
+	TabSize int
+
+	// Markup turns on inline tags for styling the text: [b]bold[/b],
+	// [i]italic[/i], and [colour=#ff0000]red[/colour]. A bracket is written as
+	// [[, and tags that are not recognized are shown as they are.
+	Markup bool
+}
+
+// PlacedGlyph is a character that has been laid out, relative to the top left
+// corner of the text.
+type PlacedGlyph struct {
+	// Char is the character itself, including spaces and tabs.
+	Char rune
+
+	// Offset is where the character starts in the text that was laid out, in
+	// bytes, including any markup before it.
+	Offset int
+
+	// Line is the number of the line the character is on, starting from zero.
+	Line int
+
+	// X, Y, Width, and Height are the area the character takes up, with the
+	// width being how far it advances the line.
+	X, Y, Width, Height int32
+
+	Font   Font
+	Colour ecs.Colour
+}
+
+// TextLayout is text that has been broken into lines and placed, ready to be
+// drawn or tested against the mouse.
+type TextLayout struct {
+	Glyphs        []PlacedGlyph
+	Lines         int
+	Width, Height int32
+}
+
+// Hit returns the glyph at the position relative to the top left corner of the
+// text. Positions beside a line give the glyph nearest to them on that line.
+func (layout *TextLayout) Hit(x, y int32) (PlacedGlyph, bool) {
+	var nearest = -1
+
+	for index, glyph := range layout.Glyphs {
+		if y < glyph.Y || y >= glyph.Y+glyph.Height {
+			continue
+		}
+
+		if x >= glyph.X && x < glyph.X+glyph.Width {
+			return glyph, true
+		}
+
+		if -1 == nearest || distance(x, glyph) < distance(x, layout.Glyphs[nearest]) {
+			nearest = index
+		}
+	}
+
+	if -1 == nearest {
+		return PlacedGlyph{}, false
+	}
+
+	return layout.Glyphs[nearest], true
+}
+
+// distance is how far the position is from either side of the glyph.
+func distance(x int32, glyph PlacedGlyph) int32 {
+	if x < glyph.X {
+		return glyph.X - x
+	}
+
+	return x - (glyph.X + glyph.Width)
+}
+
+// styled is a character of text along with the style markup gave it.
+type styled struct {
+	char   rune
+	offset int
+	font   Font
+	colour ecs.Colour
+}
+
+// LayoutText will break the text into lines and place each character, without
+// drawing anything.
+func LayoutText(text string, style TextStyle) *TextLayout {
+	if 0 == style.TabSize {
+		style.TabSize = DefaultTabSize
+	}
+
+	var layout = new(TextLayout)
+	var lines [][]PlacedGlyph
+	var line []PlacedGlyph
+	var x int32
+	var wrap = -1 // the index in the line after it's last space
+
+	var finish = func() {
+		lines = append(lines, line)
+		line, x, wrap = nil, 0, -1
+	}
+
+	for _, char := range parseMarkup(text, style) {
+		var glyph = PlacedGlyph{
+			Char:   char.char,
+			Offset: char.offset,
+			X:      x,
+			Height: char.font.Height(),
+			Font:   char.font,
+			Colour: char.colour,
+		}
+
+		switch char.char {
+		case '\n':
+			finish()
+
+			continue
+		case '\t':
+			var stop = int32(style.TabSize) * char.font.Advance(' ')
+
+			if stop > 0 {
+				glyph.Width = ((x/stop)+1)*stop - x
+			}
+		default:
+			glyph.Width = char.font.Advance(char.char)
+		}
+
+		// spaces may hang past the end of the line, but nothing else may
+		if 0 != style.Width && x+glyph.Width > style.Width && !isSpace(char.char) && len(line) > 0 {
+			var carried []PlacedGlyph
+
+			if wrap > 0 {
+				carried = append(carried, line[wrap:]...)
+				line = line[:wrap]
+			}
+
+			finish()
+
+			for _, moved := range carried {
+				moved.X = x
+				x += moved.Width
+				line = append(line, moved)
+			}
+
+			glyph.X = x
+		}
+
+		line = append(line, glyph)
+		x += glyph.Width
+
+		if isSpace(char.char) {
+			wrap = len(line)
+		}
+	}
+
+	finish()
+
+	var width = style.Width
+	var widths = make([]int32, len(lines))
+
+	for number, placed := range lines {
+		widths[number] = lineWidth(placed)
+
+		if 0 == style.Width && widths[number] > width {
+			width = widths[number]
+		}
+	}
+
+	var y int32
+
+	for number, placed := range lines {
+		var height = lineHeight(placed, style.Font)
+		var offset int32
+
+		switch style.Align {
+		case AlignCentre:
+			offset = (width - widths[number]) / 2
+		case AlignRight:
+			offset = width - widths[number]
+		}
+
+		for _, glyph := range placed {
+			glyph.Line = number
+			glyph.X += offset
+			glyph.Y = y
+			glyph.Height = height
+			layout.Glyphs = append(layout.Glyphs, glyph)
+		}
+
+		y += height
+	}
+
+	layout.Lines = len(lines)
+	layout.Width = width
+	layout.Height = y
+
+	return layout
+}
+
+// lineWidth is the width of the line, not counting spaces hanging off it's end.
+func lineWidth(line []PlacedGlyph) int32 {
+	for last := len(line) - 1; last >= 0; last-- {
+		if !isSpace(line[last].Char) {
+			return line[last].X + line[last].Width
+		}
+	}
+
+	return 0
+}
+
+// lineHeight is the height of the tallest font on the line.
+func lineHeight(line []PlacedGlyph, font Font) int32 {
+	var height = font.Height()
+
+	for _, glyph := range line {
+		if glyph.Height > height {
+			height = glyph.Height
+		}
+	}
+
+	return height
+}
+
+func isSpace(char rune) bool {
+	return ' ' == char || '\t' == char
+}
+
+// parseMarkup will split the text into characters, styling each one by the
+// markup around it.
+func parseMarkup(text string, style TextStyle) []styled {
+	var chars = make([]styled, 0, len(text))
+	var bold, italic int
+	var colours = []ecs.Colour{style.Colour}
+
+	for offset := 0; offset < len(text); {
+		var char, size = utf8.DecodeRuneInString(text[offset:])
+
+		if style.Markup && '[' == char {
+			if strings.HasPrefix(text[offset:], "[[") {
+				chars = append(chars, styled{'[', offset, pick(style, bold, italic), colours[len(colours)-1]})
+				offset += 2
+
+				continue
+			}
+
+			if end := strings.IndexByte(text[offset:], ']'); end > 0 {
+				var tag = text[offset+1 : offset+end]
+				var known = true
+
+				switch {
+				case "b" == tag:
+					bold++
+				case "/b" == tag && bold > 0:
+					bold--
+				case "i" == tag:
+					italic++
+				case "/i" == tag && italic > 0:
+					italic--
+				case ("/colour" == tag || "/color" == tag) && len(colours) > 1:
+					colours = colours[:len(colours)-1]
+				case strings.HasPrefix(tag, "colour=") || strings.HasPrefix(tag, "color="):
+					var colour, ok = parseColour(tag[strings.IndexByte(tag, '=')+1:])
+
+					if known = ok; ok {
+						colours = append(colours, colour)
+					}
+				default:
+					known = false
+				}
+
+				if known {
+					offset += end + 1
+
+					continue
+				}
+			}
+		}
+
+		chars = append(chars, styled{char, offset, pick(style, bold, italic), colours[len(colours)-1]})
+		offset += size
+	}
+
+	return chars
+}
+
+// pick returns the closest font the style has to the one asked for.
+func pick(style TextStyle, bold, italic int) Font {
+	var candidates []Font
+
+	switch {
+	case bold > 0 && italic > 0:
+		candidates = []Font{style.BoldItalic, style.Bold, style.Italic}
+	case bold > 0:
+		candidates = []Font{style.Bold}
+	case italic > 0:
+		candidates = []Font{style.Italic}
+	}
+
+	for _, font := range candidates {
+		if nil != font {
+			return font
+		}
+	}
+
+	return style.Font
+}
+
+// parseColour reads a colour written in hexadecimal, as #rgb or #rrggbb.
+func parseColour(hex string) (ecs.Colour, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+
+	if 3 == len(hex) {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if 6 != len(hex) {
+		return ecs.Colour{}, false
+	}
+
+	var value, err = strconv.ParseUint(hex, 16, 32)
+
+	if err != nil {
+		return ecs.Colour{}, false
+	}
+
+	return ecs.Colour{Red: byte(value >> 16), Green: byte(value >> 8), Blue: byte(value)}, true
+}
+
+// DrawLayout will draw the laid out text with it's top left corner at the
+// position.
+func (engine *Engine) DrawLayout(layout *TextLayout, x, y int32) error {
+	for _, glyph := range layout.Glyphs {
+		if isSpace(glyph.Char) {
+			continue
+		}
+
+		var atlas, err = engine.atlas(glyph.Font)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err = atlas.glyph(glyph.Char); err != nil {
+			return err
+		}
+	}
+
+	var tinted = make(map[*glyphAtlas]bool)
+
+	for _, glyph := range layout.Glyphs {
+		if isSpace(glyph.Char) {
+			continue
+		}
+
+		var atlas = engine.atlases[glyph.Font]
+
+		if err := atlas.upload(engine.backend); err != nil {
+			return err
+		}
+
+		var source = atlas.glyphs[glyph.Char].source
+
+		atlas.texture.Tint(glyph.Colour)
+		engine.backend.Blit(atlas.texture, &source, &Region{x + glyph.X, y + glyph.Y, source.W, source.H})
+
+		tinted[atlas] = true
+	}
+
+	for atlas := range tinted {
+		atlas.texture.Tint(White())
+	}
+
+	return nil
+}
@@ -0,0 +1,311 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+)
+
+// AssetLoaded is emitted when an asset loaded in the background is ready to
+// use, or failed to load. Handles to an asset that failed are no longer valid.
+type AssetLoaded struct {
+	Handle AssetHandle
+	Path   string
+	Err    error
+}
+
+// Name of the event.
+func (AssetLoaded) Name() string {
+	return "asset_loaded"
+}
+
+// LoadProgress is emitted each time an asset loaded in the background is
+// finished, with how many of the assets started so far are done.
+type LoadProgress struct {
+	Loaded, Total int
+}
+
+// Name of the event.
+func (LoadProgress) Name() string {
+	return "load_progress"
+}
+
+// Fraction returns how much of the loading is done, from 0 to 1.
+func (progress LoadProgress) Fraction() float32 {
+	if 0 == progress.Total {
+		return 1
+	}
+
+	return float32(progress.Loaded) / float32(progress.Total)
+}
+
+// LoadComplete is emitted once every asset being loaded in the background is
+// finished, whether or not they all loaded successfully.
+type LoadComplete struct{}
+
+// Name of the event.
+func (LoadComplete) Name() string {
+	return "load_complete"
+}
+
+// finisher completes loading an asset on the main thread, returning it's value
+// and how to unload it.
+type finisher func() (interface{}, func(), error)
+
+// loaded is an asset a worker is done with, waiting to be finished.
+type loaded struct {
+	id     uint32
+	asset  *asset
+	handle AssetHandle
+	path   string
+	finish finisher
+}
+
+// LoadTextureAsync will decode the image at the path on a worker goroutine,
+// returning a handle to it straight away. The texture is nil until it has been
+// uploaded on the main thread and AssetLoaded is emitted.
+func (manager *AssetManager) LoadTextureAsync(path string) TextureHandle {
+	path = cleanPath(path)
+
+	var backend = manager.engine.backend
+	var id = manager.loadAsync("texture", path, path, func(id uint32) AssetHandle {
+		return TextureHandle(id)
+	}, func() finisher {
+		var data, err = manager.engine.read("texture", path)
+
+		if err != nil {
+			return failed(err)
+		}
+
+		var decoded image.Image
+
+		if decoded, _, err = image.Decode(bytes.NewReader(data)); err == image.ErrFormat {
+			// leave formats only the backend understands for it to decode
+			return func() (interface{}, func(), error) {
+				return uploaded(backend.LoadTexture(data))
+			}
+		} else if err != nil {
+			return failed(assetError("texture", path, err))
+		}
+
+		return func() (interface{}, func(), error) {
+			return uploaded(backend.UploadTexture(decoded))
+		}
+	})
+
+	return TextureHandle(id)
+}
+
+// LoadFontAsync will read the font at the path on a worker goroutine, returning
+// a handle to it straight away. The font is nil until it has been opened on the
+// main thread and AssetLoaded is emitted.
+func (manager *AssetManager) LoadFontAsync(path string, size int) FontHandle {
+	path = cleanPath(path)
+
+	var id = manager.loadAsync("font", fmt.Sprintf("%s@%d", path, size), path, func(id uint32) AssetHandle {
+		return FontHandle(id)
+	}, func() finisher {
+		var data, err = manager.engine.read("font", path)
+
+		if err != nil {
+			return failed(err)
+		}
+
+		return func() (interface{}, func(), error) {
+			var font, err = manager.engine.backend.LoadFont(data, size)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return font, func() {
+				manager.engine.forget(font)
+				font.Close()
+			}, nil
+		}
+	})
+
+	return FontHandle(id)
+}
+
+// LoadSoundAsync will read the sound at the path on a worker goroutine,
+// returning a handle to it straight away. The sound is nil until it has been
+// decoded by the mixer on the main thread and AssetLoaded is emitted.
+func (manager *AssetManager) LoadSoundAsync(path string) SoundHandle {
+	path = cleanPath(path)
+
+	var id = manager.loadAsync("sound", path, path, func(id uint32) AssetHandle {
+		return SoundHandle(id)
+	}, func() finisher {
+		var data, err = manager.engine.read("sound", path)
+
+		if err != nil {
+			return failed(err)
+		}
+
+		return func() (interface{}, func(), error) {
+			var sound, err = manager.engine.mixer.LoadSound(data)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return sound, sound.Destroy, nil
+		}
+	})
+
+	return SoundHandle(id)
+}
+
+// LoadMusicAsync will read the music at the path on a worker goroutine,
+// returning a handle to it straight away. The music is nil until it has been
+// opened by the mixer on the main thread and AssetLoaded is emitted.
+func (manager *AssetManager) LoadMusicAsync(path string) MusicHandle {
+	path = cleanPath(path)
+
+	var id = manager.loadAsync("music", path, path, func(id uint32) AssetHandle {
+		return MusicHandle(id)
+	}, func() finisher {
+		var data, err = manager.engine.read("music", path)
+
+		if err != nil {
+			return failed(err)
+		}
+
+		return func() (interface{}, func(), error) {
+			var music, err = manager.engine.mixer.LoadMusic(data)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return music, music.Destroy, nil
+		}
+	})
+
+	return MusicHandle(id)
+}
+
+// Progress reports how many of the assets being loaded in the background are
+// done. Both are reset to zero once they all are.
+func (manager *AssetManager) Progress() (loaded, total int) {
+	return manager.loaded, manager.total
+}
+
+// Loading tells if any assets are still being loaded in the background.
+func (manager *AssetManager) Loading() bool {
+	return manager.loaded < manager.total
+}
+
+// Wait will block until every asset being loaded in the background is done.
+func (manager *AssetManager) Wait() {
+	for manager.Loading() {
+		manager.finish(<-manager.results)
+	}
+}
+
+// update will finish every asset the workers are done with, without waiting for
+// the rest. It is run once per frame on the main thread.
+func (manager *AssetManager) update() {
+	for {
+		select {
+		case result := <-manager.results:
+			manager.finish(result)
+		default:
+			return
+		}
+	}
+}
+
+// loadAsync will add a reference to the asset of the kind with the given key,
+// starting a worker to load it if needed. The worker's finisher is run on the
+// main thread once it is done.
+func (manager *AssetManager) loadAsync(kind, key, path string, handle func(uint32) AssetHandle, work func() finisher) uint32 {
+	var cacheKey = kind + ":" + key
+
+	if id, ok := manager.keys[cacheKey]; ok {
+		manager.assets[id].references++
+
+		return id
+	}
+
+	var pending = &asset{kind, key, 1, nil, nil, true}
+
+	manager.next++
+	manager.assets[manager.next] = pending
+	manager.keys[cacheKey] = manager.next
+	manager.total++
+
+	go func(id uint32, cancel chan struct{}) {
+		var finish finisher
+
+		select {
+		case manager.workers <- struct{}{}:
+			finish = work()
+			<-manager.workers
+		case <-cancel:
+			// everything was unloaded before a worker was free
+		}
+
+		manager.results <- loaded{id, pending, handle(id), path, finish}
+	}(manager.next, manager.cancel)
+
+	return manager.next
+}
+
+// finish will complete an asset a worker is done with, emitting it's events.
+func (manager *AssetManager) finish(result loaded) {
+	var value, unload, err = result.finish()
+	var pending = result.asset
+	var wrapped *AssetError
+
+	if nil != err && !errors.As(err, &wrapped) {
+		err = assetError(pending.kind, result.path, err)
+	}
+
+	if pending != manager.assets[result.id] {
+		// every reference was released while it was loading
+		if nil == err {
+			unload()
+		}
+	} else if nil != err {
+		delete(manager.assets, result.id)
+		delete(manager.keys, pending.kind+":"+pending.key)
+	} else {
+		pending.value, pending.unload, pending.loading = value, unload, false
+	}
+
+	manager.loaded++
+
+	if nil != manager.engine.world {
+		manager.engine.world.Emit(AssetLoaded{result.handle, result.path, err})
+		manager.engine.world.Emit(LoadProgress{manager.loaded, manager.total})
+	}
+
+	if manager.loaded < manager.total {
+		return
+	}
+
+	manager.loaded, manager.total = 0, 0
+
+	if nil != manager.engine.world {
+		manager.engine.world.Emit(LoadComplete{})
+	}
+}
+
+// failed returns a finisher for an asset a worker could not load.
+func failed(err error) finisher {
+	return func() (interface{}, func(), error) {
+		return nil, nil, err
+	}
+}
+
+// uploaded returns a texture created on the main thread and how to unload it.
+func uploaded(texture Texture, err error) (interface{}, func(), error) {
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return texture, texture.Destroy, nil
+}
@@ -4,211 +4,490 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"runtime"
+	"sort"
 	"time"
 
 	"github.com/jordanbrauer/hallucinator/pkg/ecs"
-	"github.com/veandco/go-sdl2/sdl"
-	"github.com/veandco/go-sdl2/ttf"
 )
 
 const (
 	// FPSInterval is the number of seconds that each frame counts
 	FPSInterval float32 = 1.0
+
+	// MaxFrameElapsed is the most seconds a single frame may advance the fixed
+	// update stage and tweens by. Longer frames, such as after a breakpoint or
+	// while the window is being dragged, are clamped so the simulation does not
+	// try to catch up all at once.
+	MaxFrameElapsed float32 = 0.25
+
+	// Uncapped is the frame limit that lets frames render as fast as possible.
+	Uncapped = -1
+
+	// spinThreshold is how long before the end of a frame the limiter stops
+	// sleeping and starts spinning, since sleeps are rarely that precise.
+	spinThreshold = 2 * time.Millisecond
 )
 
 // FPS is a set of various numeric values that tells a developer about how the
 // frames are being rendered.
+//
+// Frame times are in seconds, measured over the last FPSInterval.
 type FPS struct {
 	Ticks   uint32
 	Elapsed float32
 	Count   int
+	Min     float32
+	Max     float32
+	Average float32
+	P50     float32
+	P95     float32
+	P99     float32
 }
 
 type Executable func(world ecs.World) bool
 
-var debug = false
-var running = false
-var windowWidth, windowHeight int32
-
-var canvas *Canvas
-
-var keyboard []uint8
 var defaultWorldExecutable Executable = func(world ecs.World) bool {
 	return true
 }
-var update Executable
-var teardown = defaultWorldExecutable
-var setup = defaultWorldExecutable
-var frameStart time.Time
-var frameElapsed float32
-var fpsLast = sdl.GetTicks()
-var fpsCurrent int
-var fpsFrames = 0
-var world ecs.World
-
-// Init will create a new window, keyboard state, and set of pixels to draw
-// things to.
-func Init(name string, width, height int32) {
-	Abort(sdl.Init(sdl.INIT_VIDEO))
-	Abort(ttf.Init())
-
-	windowHeight = height
-	windowWidth = width
-	canvas = CreateCanvas(name, width, height)
-	keyboard = sdl.GetKeyboardState()
-	world = ecs.CreateWorld()
+
+// Init will open the backend's window and create the world.
+func (engine *Engine) Init() error {
+	engine.window = CreateWindowState(engine.width, engine.height)
+	engine.world = ecs.CreateWorld()
+	engine.world.AddResource(engine.input)
+	engine.world.AddResource(engine.actions)
+	engine.world.AddResource(engine.assets)
+	engine.world.AddResource(engine.window)
+	engine.world.AddResource(engine.tweens)
+	engine.world.RegisterEvent(GamepadConnected{}.Name())
+	engine.world.RegisterEvent(GamepadDisconnected{}.Name())
+	engine.world.RegisterEvent(WindowResized{}.Name())
+	engine.world.RegisterEvent(FocusGained{}.Name())
+	engine.world.RegisterEvent(FocusLost{}.Name())
+	engine.world.RegisterEvent(AssetLoaded{}.Name())
+	engine.world.RegisterEvent(LoadProgress{}.Name())
+	engine.world.RegisterEvent(LoadComplete{}.Name())
+
+	// these are only remembered until the window is opened
+	engine.backend.VSync(engine.vsync)
+	engine.backend.Resizable(engine.resizable)
+
+	if err := engine.backend.Display(engine.mode); err != nil {
+		return err
+	}
+
+	if err := engine.backend.Logical(engine.logicalWidth, engine.logicalHeight, engine.integer); err != nil {
+		return err
+	}
+
+	if err := engine.backend.Open(engine.title, engine.width, engine.height); err != nil {
+		return err
+	}
+
+	engine.opened = true
+
+	if engine.openAudio {
+		if err := engine.OpenAudio(); err != nil {
+			return err
+		}
+	}
 
 	rand.Seed(time.Now().UnixNano())
 	fmt.Println("Finished initializing subsystems")
+
+	return nil
 }
 
 // cleanup will safely close down the application. Before running any of the
-// subsystem cleanups, we first run the user-defined teardown function.
-func cleanup() {
-	teardown(world)
+// subsystem cleanups, we first run the user-defined teardown function. Assets
+// are unloaded, waiting for any being loaded in the background, before the
+// mixer and backend they were loaded by are closed.
+func (engine *Engine) cleanup() {
+	engine.teardown(engine.world)
 	fmt.Println("Cleaning up resources...")
-	// font.Close()
-	ttf.Quit()
-	sdl.Quit()
-	canvas.Destroy()
+	engine.assets.Report(os.Stderr)
+	engine.assets.UnloadAll()
+
+	for font := range engine.atlases {
+		engine.forget(font)
+	}
+
+	if nil != engine.audio {
+		engine.mixer.Close()
+	}
+
+	engine.backend.Close()
+	engine.opened = false
 	fmt.Println("Done!")
 }
 
-func FramesPerSecond() FPS {
-	return FPS{Ticks: fpsLast, Elapsed: frameElapsed, Count: fpsCurrent}
+// FramesPerSecond reports the current framerate, along with statistics about
+// how long frames took to render. It is only counted while debugging.
+func (engine *Engine) FramesPerSecond() FPS {
+	var fps = engine.fpsStats
+	fps.Ticks = engine.fpsLast
+	fps.Elapsed = engine.frameElapsed
+	fps.Count = engine.fpsCurrent
+
+	return fps
 }
 
 // FrameElapsed gives the caller a delta to multiply various physics based
 // calculations by, ensuring that the program runs at the same speed on all CPUs.
-func FrameElapsed() float32 {
-	return frameElapsed
+func (engine *Engine) FrameElapsed() float32 {
+	return engine.frameElapsed
+}
+
+// FixedElapsed gives the caller the number of seconds simulated by each call to
+// the fixed update closure, which is always the same.
+func (engine *Engine) FixedElapsed() float32 {
+	return 1 / engine.tickRate
+}
+
+// Alpha is how far the current frame is between the previous fixed update and
+// the next, from zero to one. Renderers can use it to interpolate between the
+// last two simulated states, so motion stays smooth when the frame rate and
+// tick rate differ.
+func (engine *Engine) Alpha() float32 {
+	return engine.alpha
+}
+
+// FixedUpdate will define the closure that is executed at a fixed rate, set by
+// TickRate, no matter how quickly frames are rendered. Physics and other
+// simulation belong here, so that they behave the same on every CPU. The
+// closure passed to Run is still executed once per frame, after any fixed
+// updates, and is where rendering belongs.
+func (engine *Engine) FixedUpdate(closure Executable) {
+	engine.fixed = closure
+}
+
+// simulate will run as many fixed updates as have accumulated over the given
+// number of seconds, keeping the remainder for the next frame.
+func (engine *Engine) simulate(dt float32) {
+	if nil == engine.fixed {
+		engine.alpha = 0
+
+		return
+	}
+
+	var step = engine.FixedElapsed()
+
+	engine.accumulator += clampElapsed(dt)
+
+	for engine.accumulator >= step && engine.running {
+		engine.index()
+		engine.running = engine.fixed(engine.world)
+		engine.accumulator -= step
+	}
+
+	engine.alpha = engine.accumulator / step
+}
+
+// clampElapsed limits the seconds a frame advances the simulation by to the
+// MaxFrameElapsed.
+func clampElapsed(dt float32) float32 {
+	if dt > MaxFrameElapsed {
+		return MaxFrameElapsed
+	}
+
+	return dt
+}
+
+// index will bring the world's spatial index, if it has one, up to date with
+// every entity's transform.
+func (engine *Engine) index() {
+	if index, ok := engine.world.Resource(ecs.SpatialIndex{}.Name()).(*ecs.SpatialIndex); ok {
+		index.Update(0)
+	}
 }
 
 // countFramesPerSecond will calculate the current FPS and return a struct full of
 // various debug information about the framerate.
-func countFramesPerSecond() {
-	fpsFrames++
-
-	if fpsLast < (sdl.GetTicks() - uint32((FPSInterval * 1000.0))) {
-		fpsLast = sdl.GetTicks()
-		fpsCurrent = fpsFrames
-		fpsFrames = 0
+func (engine *Engine) countFramesPerSecond() {
+	engine.fpsFrames++
+	engine.frameTimes = append(engine.frameTimes, engine.frameElapsed)
+
+	if engine.fpsLast < (engine.ticks() - uint32((FPSInterval * 1000.0))) {
+		engine.fpsLast = engine.ticks()
+		engine.fpsCurrent = engine.fpsFrames
+		engine.fpsFrames = 0
+		engine.fpsStats = frameStatistics(engine.frameTimes)
+		engine.frameTimes = engine.frameTimes[:0]
 	}
 }
 
-func countFrameElapsed() {
-	frameElapsed = float32(time.Since(frameStart).Seconds())
+// frameStatistics summarizes the given frame times.
+func frameStatistics(times []float32) FPS {
+	var stats FPS
 
-	if frameElapsed < 0.005 {
-		sdl.Delay(5 - uint32((frameElapsed * 1000.0)))
+	if 0 == len(times) {
+		return stats
+	}
 
-		frameElapsed = float32(time.Since(frameStart).Seconds())
+	var sorted = make([]float32, len(times))
+	var total float32
+
+	copy(sorted, times)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	for _, elapsed := range sorted {
+		total += elapsed
+	}
+
+	var percentile = func(p float32) float32 {
+		return sorted[int(p*float32(len(sorted)-1))]
 	}
+
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	stats.Average = total / float32(len(sorted))
+	stats.P50 = percentile(0.50)
+	stats.P95 = percentile(0.95)
+	stats.P99 = percentile(0.99)
+
+	return stats
 }
 
-// Debug sets the application's debug mode to the given boolean.
-func Debug(enabled bool) {
-	debug = enabled
+func (engine *Engine) countFrameElapsed() {
+	engine.pace()
+
+	engine.frameElapsed = float32(time.Since(engine.frameStart).Seconds())
 }
 
-// Debugging is used to determine if the application is currently running in
-// debug mode.
-func Debugging() bool {
-	return debug
+// pace will wait out the rest of the current frame when a frame limit is set.
+// Most of the wait is slept, and the last moments are spun through, which is
+// far more precise than sleeping alone.
+func (engine *Engine) pace() {
+	if engine.frameLimit <= 0 {
+		return
+	}
+
+	var end = engine.frameStart.Add(time.Second / time.Duration(engine.frameLimit))
+
+	if remaining := time.Until(end) - spinThreshold; remaining > 0 {
+		time.Sleep(remaining)
+	}
+
+	for time.Now().Before(end) {
+		runtime.Gosched()
+	}
+}
+
+// ticks is the number of milliseconds since the engine was created. It does not
+// rely on the backend, so that it is the same no matter which is in use.
+func (engine *Engine) ticks() uint32 {
+	return uint32(time.Since(engine.epoch).Milliseconds())
 }
 
 // handleEvents will poll the operating system events and perform some behaviour
 // based on the events being listened on.
-func handleEvents() bool {
-	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-		switch event.(type) {
-		case *sdl.QuitEvent:
-			running = false
+func (engine *Engine) handleEvents() bool {
+	if !engine.backend.Poll(engine.input, engine.window) && engine.running {
+		engine.running = false
+
+		fmt.Println("\nReceived shutdown event!")
+	}
 
-			fmt.Println("\nReceived shutdown event!")
+	return engine.running
+}
 
-			break
-		}
+// emitInputEvents will tell the world about gamepads that were plugged in or
+// unplugged during the frame.
+func (engine *Engine) emitInputEvents() {
+	for _, gamepad := range engine.input.JustConnected() {
+		engine.world.Emit(GamepadConnected{gamepad})
 	}
 
-	return running
+	for _, id := range engine.input.JustDisconnected() {
+		engine.world.Emit(GamepadDisconnected{id})
+	}
 }
 
 // Setup will define the closure that is executed once during the application
 // runtime, right before it begins looping and executing the main loop closure.
-func Setup(closure Executable) {
-	setup = closure
+func (engine *Engine) Setup(closure Executable) {
+	engine.setup = closure
 }
 
 // Teardown will define the closure that is executed once during the application
 // cleanup setup, right before the subsytstems are torn down.
-func Teardown(closure Executable) {
-	teardown = closure
+func (engine *Engine) Teardown(closure Executable) {
+	engine.teardown = closure
 }
 
 // Run will execute the main program logic in an infinite loop until the closure
 // returns false or an operating system event causes the loop to end.
-func Run(update Executable) {
-	running = true
+func (engine *Engine) Run(update Executable) {
+	engine.Fatal(engine.Start())
 
-	setup(world)
+	for engine.Running() {
+		engine.frameStart = time.Now()
 
-	for Running() {
-		frameStart = time.Now()
+		engine.Step(update, engine.frameElapsed)
+		engine.countFrameElapsed()
+	}
 
-		handleEvents()
-		canvas.Clear()
+	engine.Stop()
+}
 
-		running = running && update(world)
+// Start will run the setup closure and mark the program as running, without
+// entering the main loop. Use it along with Step and Stop to drive the program
+// one frame at a time, such as from a test. ErrNotInitialized is returned if
+// Init has not opened the backend.
+func (engine *Engine) Start() error {
+	if !engine.opened {
+		return ErrNotInitialized
+	}
 
-		canvas.Display()
+	engine.running = true
+	engine.accumulator = 0
+	engine.alpha = 0
 
-		if Debugging() {
-			countFramesPerSecond()
-		}
+	engine.setup(engine.world)
+
+	return nil
+}
+
+// Step will run a single frame of the main loop with the given closure, as if
+// the given number of seconds had elapsed since the previous frame. It returns
+// false once the program has stopped running, or if it was never started.
+func (engine *Engine) Step(update Executable, dt float32) bool {
+	if !engine.Running() {
+		return false
+	}
+
+	engine.frameElapsed = dt
+
+	engine.handleEvents()
+	engine.assets.update()
+	engine.handleWindow()
+	engine.emitInputEvents()
+	engine.simulate(dt)
+	engine.tweens.Update(clampElapsed(dt))
+	engine.index()
+	engine.backend.Clear()
+
+	engine.running = engine.running && update(engine.world)
+
+	if nil != engine.audio {
+		engine.world.Update(engine.audio.Name(), dt)
+	}
+
+	engine.backend.Present()
+	engine.world.Flush()
+	engine.actions.advance()
+	engine.input.advance()
+	engine.window.advance()
 
-		countFrameElapsed()
+	if engine.Debugging() {
+		engine.countFramesPerSecond()
 	}
 
-	cleanup()
+	return engine.running
+}
+
+// Stop will end the program, running the teardown closure and cleaning up all
+// subsystems.
+func (engine *Engine) Stop() {
+	engine.running = false
+
+	engine.cleanup()
 }
 
 // Running will tell the caller if the loop or main program is currently running.
-func Running() bool {
-	return running
+func (engine *Engine) Running() bool {
+	return engine.running
+}
+
+// Render will copy the source area of the texture to the destination area of
+// the screen. A nil area is the entire texture or screen.
+func (engine *Engine) Render(texture Texture, source, dest *Region) {
+	engine.backend.Blit(texture, source, dest)
 }
 
-func Render(texture *sdl.Texture, source, dest *sdl.Rect) {
-	canvas.Render(texture, source, dest)
+// RenderEx will render the same as Render, turning the texture clockwise by the
+// angle in radians around the centre of the destination, and flipping it.
+func (engine *Engine) RenderEx(texture Texture, source, dest *Region, angle float32, flip Flip) {
+	engine.backend.BlitEx(texture, source, dest, angle, flip)
+}
+
+// LoadFont will open the font file at the given point size. A missing file is
+// reported as an AssetError wrapping ErrMissingAsset.
+func (engine *Engine) LoadFont(path string, size int) (Font, error) {
+	var data, err = engine.read("font", path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var font Font
+
+	font, err = engine.backend.LoadFont(data, size)
+
+	if err != nil {
+		return nil, assetError("font", path, err)
+	}
+
+	return font, nil
 }
 
-func LoadFont(path string, size int) *ttf.Font {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		Abort(sdl.ShowSimpleMessageBox(
-			sdl.MESSAGEBOX_ERROR,
-			"Missing Font",
-			fmt.Sprintf("Unable to locate font at %s. Exiting program now.", path),
-			canvas.window,
-		))
-		os.Exit(1)
+// LoadTexture will create a new texture from the given file path. A missing
+// file is reported as an AssetError wrapping ErrMissingAsset.
+func (engine *Engine) LoadTexture(path string) (Texture, error) {
+	var data, err = engine.read("texture", path)
+
+	if err != nil {
+		return nil, err
 	}
 
-	var font, err = ttf.OpenFont(path, size)
+	var texture Texture
 
-	Abort(err)
+	texture, err = engine.backend.LoadTexture(data)
 
-	return font
+	if err != nil {
+		return nil, assetError("texture", path, err)
+	}
+
+	return texture, nil
 }
 
-func LoadTexture(path string) *sdl.Texture {
-	return canvas.LoadTexture(path)
+// CreateTexture will create a new, empty texture of the given size that pixels
+// can be streamed to.
+func (engine *Engine) CreateTexture(width, height int32) (Texture, error) {
+	return engine.backend.CreateTexture(width, height)
+}
+
+// TexturizeString will render the text with the given font to a new texture,
+// in white so that it can be coloured with Tint. The texture must be destroyed
+// once it is no longer needed, so text that changes every frame should be drawn
+// with DrawText instead.
+func (engine *Engine) TexturizeString(font Font, text string) (Texture, error) {
+	return engine.backend.RenderText(font, text, White())
+}
+
+// LoadGamepadMappings will add the gamepad mappings in the file, in the format
+// of the community SDL_GameControllerDB, so that more gamepads are recognized.
+func (engine *Engine) LoadGamepadMappings(path string) error {
+	var data, err = engine.read("gamepad mappings", path)
+
+	if err != nil {
+		return err
+	}
+
+	return engine.backend.LoadGamepadMappings(data)
 }
 
-func CreateTexture(width, height int32) *sdl.Texture {
-	return canvas.CreateTexture(width, height)
+// AttachVirtualGamepad will plug in a gamepad that exists only in software, for
+// testing gamepad support without any hardware.
+func (engine *Engine) AttachVirtualGamepad() (VirtualGamepad, error) {
+	return engine.backend.AttachVirtualGamepad()
 }
 
-func TexturizeString(font *ttf.Font, text string) *sdl.Texture {
-	// TODO: get colour from graphics? fill, etc.
-	return canvas.TexturizeString(text, font, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+// IsKeyPressed checks if the given key is actively being held or was pressed by
+// the user.
+func (engine *Engine) IsKeyPressed(key Key) bool {
+	return engine.input.Held(key)
 }
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+	"github.com/veandco/go-sdl2/mix"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// CreateSDLMixer returns a mixer that plays audio through SDL2 Mixer. This is
+// the default mixer.
+func CreateSDLMixer() Mixer {
+	return new(sdlMixer)
+}
+
+type sdlMixer struct{}
+
+type sdlSound struct {
+	chunk *mix.Chunk
+}
+
+type sdlMusic struct {
+	music *mix.Music
+	data  []byte // streamed from for as long as the music is loaded
+}
+
+func (mixer *sdlMixer) Open(channels int) error {
+	if err := sdl.InitSubSystem(sdl.INIT_AUDIO); err != nil {
+		return err
+	}
+
+	// formats that are not available can still be loaded as WAV
+	mix.Init(mix.INIT_OGG | mix.INIT_MP3)
+
+	if err := mix.OpenAudio(mix.DEFAULT_FREQUENCY, mix.DEFAULT_FORMAT, mix.DEFAULT_CHANNELS, 1024); err != nil {
+		return err
+	}
+
+	mix.AllocateChannels(channels)
+
+	return nil
+}
+
+func (mixer *sdlMixer) Close() {
+	mix.HaltChannel(AnyChannel)
+	mix.HaltMusic()
+	mix.CloseAudio()
+	mix.Quit()
+	sdl.QuitSubSystem(sdl.INIT_AUDIO)
+}
+
+func (mixer *sdlMixer) LoadSound(data []byte) (Sound, error) {
+	var source, err = sdl.RWFromMem(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var chunk *mix.Chunk
+
+	if chunk, err = mix.LoadWAVRW(source, true); err != nil {
+		return nil, err
+	}
+
+	return &sdlSound{chunk}, nil
+}
+
+func (mixer *sdlMixer) LoadMusic(data []byte) (Music, error) {
+	var source, err = sdl.RWFromMem(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var music *mix.Music
+
+	if music, err = mix.LoadMUSRW(source, 1); err != nil {
+		return nil, err
+	}
+
+	return &sdlMusic{music, data}, nil
+}
+
+func (mixer *sdlMixer) Play(sound Sound, channel, loops int, fade float32) (int, error) {
+	var chunk = sound.(*sdlSound).chunk
+
+	if fade > 0 {
+		return chunk.FadeIn(channel, loops, milliseconds(fade))
+	}
+
+	return chunk.Play(channel, loops)
+}
+
+func (mixer *sdlMixer) Stop(channel int, fade float32) {
+	if fade > 0 {
+		mix.FadeOutChannel(channel, milliseconds(fade))
+
+		return
+	}
+
+	mix.HaltChannel(channel)
+}
+
+func (mixer *sdlMixer) Pause(channel int) {
+	mix.Pause(channel)
+}
+
+func (mixer *sdlMixer) Resume(channel int) {
+	mix.Resume(channel)
+}
+
+func (mixer *sdlMixer) Playing(channel int) bool {
+	return 0 != mix.Playing(channel)
+}
+
+func (mixer *sdlMixer) Volume(channel int, volume float32) {
+	mix.Volume(channel, mixerVolume(volume))
+}
+
+// Pan keeps the near side at full volume and quietens the far side, so that a
+// centred channel is as loud as it would be without panning. SDL2 Mixer only
+// fails to pan when the effect cannot be registered on the channel, which then
+// carries on playing from the centre, so the error is not returned.
+func (mixer *sdlMixer) Pan(channel int, pan float32) {
+	pan = maths.Clamp(pan, -1, 1)
+
+	mix.SetPanning(
+		channel,
+		uint8(255*maths.Min(1, 1-pan)),
+		uint8(255*maths.Min(1, 1+pan)),
+	)
+}
+
+func (mixer *sdlMixer) PlayMusic(music Music, loops int, fade float32) error {
+	var playing = music.(*sdlMusic).music
+
+	if fade > 0 {
+		return playing.FadeIn(loops, milliseconds(fade))
+	}
+
+	return playing.Play(loops)
+}
+
+func (mixer *sdlMixer) StopMusic(fade float32) {
+	if fade > 0 {
+		mix.FadeOutMusic(milliseconds(fade))
+
+		return
+	}
+
+	mix.HaltMusic()
+}
+
+func (mixer *sdlMixer) PauseMusic() {
+	mix.PauseMusic()
+}
+
+func (mixer *sdlMixer) ResumeMusic() {
+	mix.ResumeMusic()
+}
+
+func (mixer *sdlMixer) MusicVolume(volume float32) {
+	mix.VolumeMusic(mixerVolume(volume))
+}
+
+func (sound *sdlSound) Destroy() {
+	sound.chunk.Free()
+}
+
+func (music *sdlMusic) Destroy() {
+	music.music.Free()
+}
+
+// mixerVolume converts a volume from 0 to 1 to SDL2 Mixer's.
+func mixerVolume(volume float32) int {
+	return int(maths.Clamp(volume, 0, 1) * mix.MAX_VOLUME)
+}
+
+// milliseconds converts seconds to SDL2 Mixer's whole milliseconds.
+func milliseconds(seconds float32) int {
+	return int(seconds * 1000)
+}
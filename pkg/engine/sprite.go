@@ -0,0 +1,350 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// ErrNoAtlasImage is returned when a sprite atlas does not name the image that
+// it's frames are cut from.
+var ErrNoAtlasImage = errors.New("sprite atlas has no image")
+
+// SpriteSheet is a texture divided into frames, numbered from zero. Frames are
+// either laid out in a grid, or cut out by a JSON atlas.
+type SpriteSheet struct {
+	Texture TextureHandle
+	Frames  []Region
+
+	// Columns in the grid, or zero when the frames are from an atlas.
+	Columns int32
+
+	names map[string]int
+	clips map[string]*AnimationClip
+}
+
+// CreateSpriteGrid returns a sheet of frames of the same size, laid out in
+// columns and rows from the top left of the texture. Frames are numbered left
+// to right, then top to bottom.
+func CreateSpriteGrid(texture TextureHandle, width, height, columns, rows int32) *SpriteSheet {
+	var sheet = &SpriteSheet{Texture: texture, Columns: columns}
+
+	for row := int32(0); row < rows; row++ {
+		for column := int32(0); column < columns; column++ {
+			sheet.Frames = append(sheet.Frames, Region{column * width, row * height, width, height})
+		}
+	}
+
+	return sheet
+}
+
+// atlasRegion is the area of a frame in a sprite atlas.
+type atlasRegion struct {
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+	W int32 `json:"w"`
+	H int32 `json:"h"`
+}
+
+// atlasFrame is a named frame in a sprite atlas.
+type atlasFrame struct {
+	Filename string      `json:"filename"`
+	Frame    atlasRegion `json:"frame"`
+	Duration int         `json:"duration"`
+}
+
+// atlasTag is a named range of frames in a sprite atlas, played as a clip.
+type atlasTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+}
+
+// spriteAtlas is a sprite atlas in the JSON format written by TexturePacker and
+// Aseprite, with it's frames either in an array or keyed by name.
+type spriteAtlas struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image     string     `json:"image"`
+		FrameTags []atlasTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// ParseSpriteAtlas returns a sheet of the frames described by the JSON atlas.
+// Frames keep the order they are written in, whether listed in an array or
+// keyed by name, as frame tags refer to them by their position. Frame tags
+// written by Aseprite are added as clips, using the duration of each frame.
+func ParseSpriteAtlas(texture TextureHandle, data []byte) (*SpriteSheet, error) {
+	var atlas spriteAtlas
+
+	if err := json.Unmarshal(data, &atlas); err != nil {
+		return nil, err
+	}
+
+	var frames []atlasFrame
+
+	if err := json.Unmarshal(atlas.Frames, &frames); err != nil {
+		if frames, err = keyedFrames(atlas.Frames); err != nil {
+			return nil, err
+		}
+	}
+
+	var sheet = &SpriteSheet{Texture: texture, names: make(map[string]int)}
+
+	for index, frame := range frames {
+		sheet.Frames = append(sheet.Frames, Region{frame.Frame.X, frame.Frame.Y, frame.Frame.W, frame.Frame.H})
+		sheet.names[frame.Filename] = index
+	}
+
+	for _, tag := range atlas.Meta.FrameTags {
+		var clip = &AnimationClip{Name: tag.Name, Playback: Loop}
+
+		for index := tag.From; index <= tag.To && index < len(frames); index++ {
+			clip.Frames = append(clip.Frames, AnimationFrame{
+				Index:    index,
+				Duration: float32(frames[index].Duration) / 1000,
+			})
+		}
+
+		switch tag.Direction {
+		case "pingpong":
+			clip.Playback = PingPong
+		case "reverse":
+			for left, right := 0, len(clip.Frames)-1; left < right; left, right = left+1, right-1 {
+				clip.Frames[left], clip.Frames[right] = clip.Frames[right], clip.Frames[left]
+			}
+		}
+
+		sheet.AddClip(clip)
+	}
+
+	return sheet, nil
+}
+
+// keyedFrames reads frames keyed by name in the order they are written, which a
+// map would lose.
+func keyedFrames(data []byte) ([]atlasFrame, error) {
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	var frames []atlasFrame
+
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if json.Delim('{') != token {
+		return nil, fmt.Errorf("sprite atlas frames must be an array or object, not %v", token)
+	}
+
+	for decoder.More() {
+		var token, err = decoder.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		var frame atlasFrame
+
+		if err = decoder.Decode(&frame); err != nil {
+			return nil, err
+		}
+
+		frame.Filename = token.(string)
+		frames = append(frames, frame)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return frames, nil
+}
+
+// LoadSpriteSheet will load the JSON atlas at the path, along with the image it
+// names, which is found relative to the atlas. The sheet's texture must be
+// released once it is no longer needed.
+func (manager *AssetManager) LoadSpriteSheet(file string) (*SpriteSheet, error) {
+	var data, err = manager.engine.read("sprite sheet", file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var atlas spriteAtlas
+
+	if err = json.Unmarshal(data, &atlas); err != nil {
+		return nil, assetError("sprite sheet", file, err)
+	}
+
+	if "" == atlas.Meta.Image {
+		return nil, assetError("sprite sheet", file, ErrNoAtlasImage)
+	}
+
+	var texture TextureHandle
+
+	if texture, err = manager.LoadTexture(path.Join(path.Dir(file), atlas.Meta.Image)); err != nil {
+		return nil, err
+	}
+
+	var sheet *SpriteSheet
+
+	if sheet, err = ParseSpriteAtlas(texture, data); err != nil {
+		manager.Release(texture)
+
+		return nil, assetError("sprite sheet", file, err)
+	}
+
+	return sheet, nil
+}
+
+// Index returns the number of the frame in the grid's column and row.
+func (sheet *SpriteSheet) Index(column, row int32) int {
+	return int((row * sheet.Columns) + column)
+}
+
+// Cell returns the column and row of the numbered frame in the grid.
+func (sheet *SpriteSheet) Cell(index int) (column, row int32) {
+	if 0 == sheet.Columns {
+		return 0, 0
+	}
+
+	return int32(index) % sheet.Columns, int32(index) / sheet.Columns
+}
+
+// Frame returns the number of the frame with the name given to it by the atlas.
+func (sheet *SpriteSheet) Frame(name string) (int, bool) {
+	var index, ok = sheet.names[name]
+
+	return index, ok
+}
+
+// Sprite draws a frame of a sprite sheet at an entity's transform. The frame is
+// drawn in it's original colours, unless given a Tint to multiply them by.
+type Sprite struct {
+	Sheet  *SpriteSheet
+	Frame  int
+	Flip   Flip
+	Tint   *ecs.Colour
+	Hidden bool
+}
+
+// CreateSprite returns a sprite showing the numbered frame of the sheet in it's
+// original colours.
+func CreateSprite(sheet *SpriteSheet, frame int) *Sprite {
+	return &Sprite{Sheet: sheet, Frame: frame}
+}
+
+// Name of the component.
+func (Sprite) Name() string {
+	return "sprite"
+}
+
+// SpriteSystem draws every entity with a Sprite and a Transform. The transform
+// positions the top left corner of the sprite, it's dimensions size it (the
+// frame's own size is used when they are zero), it's scale multiplies that
+// size, and it's rotation around the z axis turns it around it's centre. A
+// negative scale flips the sprite.
+//
+// It is also a plugin, registering both components and itself. Update it each
+// frame to draw the sprites.
+type SpriteSystem struct {
+	ecs.SystemAccess
+
+	engine *Engine
+}
+
+// CreateSpriteSystem returns a sprite system that draws through the engine.
+func CreateSpriteSystem(engine *Engine) *SpriteSystem {
+	return &SpriteSystem{engine: engine}
+}
+
+// Name of the system and plugin.
+func (system *SpriteSystem) Name() string {
+	return "sprites"
+}
+
+// Dependencies of the plugin.
+func (system *SpriteSystem) Dependencies() []string {
+	return nil
+}
+
+// Build will register the sprite and transform components and the system.
+func (system *SpriteSystem) Build(world ecs.World) {
+	var sprite = Sprite{}.Name()
+	var transform = ecs.Transform{}.Name()
+
+	world.RegisterComponent(sprite)
+	world.RegisterComponent(transform)
+	world.RegisterSystem(system, sprite, transform)
+}
+
+// Update will draw every visible sprite.
+func (system *SpriteSystem) Update(dt float32) {
+	for _, entity := range system.Entities() {
+		var sprite = system.Component(entity, Sprite{}.Name()).(*Sprite)
+		var xform = system.Component(entity, ecs.Transform{}.Name()).(*ecs.Transform)
+
+		system.draw(sprite, xform)
+	}
+}
+
+func (system *SpriteSystem) draw(sprite *Sprite, xform *ecs.Transform) {
+	if sprite.Hidden || nil == sprite.Sheet || sprite.Frame < 0 || sprite.Frame >= len(sprite.Sheet.Frames) {
+		return
+	}
+
+	var texture = system.engine.assets.Texture(sprite.Sheet.Texture)
+
+	if nil == texture {
+		return
+	}
+
+	var source = sprite.Sheet.Frames[sprite.Frame]
+	var width, height = xform.Width, xform.Height
+	var scaleX, scaleY = xform.Scale.X, xform.Scale.Y
+	var flip = sprite.Flip
+
+	if 0 == width {
+		width = float32(source.W)
+	}
+
+	if 0 == height {
+		height = float32(source.H)
+	}
+
+	// an unset scale leaves the sprite at it's size
+	if 0 == scaleX {
+		scaleX = 1
+	}
+
+	if 0 == scaleY {
+		scaleY = 1
+	}
+
+	if scaleX < 0 {
+		scaleX, flip = -scaleX, flip^FlipHorizontal
+	}
+
+	if scaleY < 0 {
+		scaleY, flip = -scaleY, flip^FlipVertical
+	}
+
+	var destination = Region{
+		X: int32(xform.Position.X),
+		Y: int32(xform.Position.Y),
+		W: int32(width * scaleX),
+		H: int32(height * scaleY),
+	}
+
+	if nil == sprite.Tint {
+		system.engine.backend.BlitEx(texture, &source, &destination, xform.Rotation.Z, flip)
+
+		return
+	}
+
+	texture.Tint(*sprite.Tint)
+	system.engine.backend.BlitEx(texture, &source, &destination, xform.Rotation.Z, flip)
+	texture.Tint(White())
+}
@@ -0,0 +1,241 @@
+package engine
+
+import (
+	"image"
+	"image/draw"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// atlasWidth is the width of every glyph atlas in pixels. Atlases grow taller
+// as more characters are drawn.
+const atlasWidth = 512
+
+// replacement is drawn in place of any character the font cannot render. It is
+// always packed, as every atlas starts with the printable ASCII characters.
+const replacement = '?'
+
+// glyph is where a character is packed in an atlas.
+type glyph struct {
+	source  Region
+	advance int32
+}
+
+// glyphAtlas holds every character drawn with a font so far, packed in rows
+// into a single texture so that text can be drawn without rendering it again.
+type glyphAtlas struct {
+	font    Font
+	pixels  *image.NRGBA
+	texture Texture
+	glyphs  map[rune]glyph
+	x, y    int
+	dirty   bool
+}
+
+// createGlyphAtlas returns an atlas for the font with the printable ASCII
+// characters already packed into it.
+func createGlyphAtlas(font Font) (*glyphAtlas, error) {
+	var atlas = &glyphAtlas{
+		font:   font,
+		pixels: image.NewNRGBA(image.Rect(0, 0, atlasWidth, int(font.Height())*4)),
+		glyphs: make(map[rune]glyph),
+	}
+
+	for char := ' '; char <= '~'; char++ {
+		if _, err := atlas.glyph(char); err != nil {
+			return nil, err
+		}
+	}
+
+	return atlas, nil
+}
+
+// glyph returns where the character is packed, packing it first if needed.
+func (atlas *glyphAtlas) glyph(char rune) (glyph, error) {
+	if packed, ok := atlas.glyphs[char]; ok {
+		return packed, nil
+	}
+
+	var rendered, err = atlas.font.Glyph(char)
+
+	if err != nil {
+		return glyph{}, err
+	}
+
+	var bounds = rendered.Bounds()
+
+	if atlas.x+bounds.Dx() > atlasWidth {
+		atlas.x = 0
+		atlas.y += int(atlas.font.Height()) + 1
+	}
+
+	// double the height whenever the rows run out of room
+	for atlas.y+bounds.Dy() > atlas.pixels.Bounds().Dy() {
+		var grown = image.NewNRGBA(image.Rect(0, 0, atlasWidth, atlas.pixels.Bounds().Dy()*2))
+
+		draw.Draw(grown, atlas.pixels.Bounds(), atlas.pixels, image.Point{}, draw.Src)
+
+		atlas.pixels = grown
+	}
+
+	var source = Region{int32(atlas.x), int32(atlas.y), int32(bounds.Dx()), int32(bounds.Dy())}
+
+	draw.Draw(atlas.pixels, image.Rect(atlas.x, atlas.y, atlas.x+bounds.Dx(), atlas.y+bounds.Dy()), rendered, bounds.Min, draw.Src)
+
+	// a pixel of space keeps neighbours from bleeding into each other
+	atlas.x += bounds.Dx() + 1
+	atlas.glyphs[char] = glyph{source, atlas.font.Advance(char)}
+	atlas.dirty = true
+
+	return atlas.glyphs[char], nil
+}
+
+// printable returns where the character is packed, or the replacement if the
+// font cannot render it. The replacement keeps the character's own advance, so
+// that text measures the same either way, and is remembered in the character's
+// place, so that the font is not asked to render it again.
+func (atlas *glyphAtlas) printable(char rune) glyph {
+	var packed, err = atlas.glyph(char)
+
+	if err != nil {
+		packed = glyph{atlas.glyphs[replacement].source, atlas.font.Advance(char)}
+		atlas.glyphs[char] = packed
+	}
+
+	return packed
+}
+
+// upload will replace the atlas' texture if characters were packed since it
+// was last uploaded.
+func (atlas *glyphAtlas) upload(backend Backend) error {
+	if !atlas.dirty {
+		return nil
+	}
+
+	var texture, err = backend.UploadTexture(atlas.pixels)
+
+	if err != nil {
+		return err
+	}
+
+	atlas.destroy()
+
+	atlas.texture = texture
+	atlas.dirty = false
+
+	return nil
+}
+
+func (atlas *glyphAtlas) destroy() {
+	if nil != atlas.texture {
+		atlas.texture.Destroy()
+	}
+
+	atlas.texture = nil
+}
+
+// DrawText will draw the text with it's top left corner at the position,
+// starting a new line at each newline. Each character is rendered only once
+// per font, the first time it is drawn, into a texture that is shared by all of
+// the font's text. Carriage returns are skipped, and characters the font cannot
+// render are drawn as a question mark.
+func (engine *Engine) DrawText(font Font, text string, x, y int32, colour ecs.Colour) error {
+	var atlas, err = engine.atlas(font)
+
+	if err != nil {
+		return err
+	}
+
+	var lines = [][]glyph{make([]glyph, 0, len(text))}
+
+	for _, char := range text {
+		if '\n' == char {
+			lines = append(lines, nil)
+
+			continue
+		}
+
+		if '\r' == char {
+			continue
+		}
+
+		lines[len(lines)-1] = append(lines[len(lines)-1], atlas.printable(char))
+	}
+
+	if err = atlas.upload(engine.backend); err != nil {
+		return err
+	}
+
+	atlas.texture.Tint(colour)
+
+	for _, line := range lines {
+		var left = x
+
+		for _, packed := range line {
+			var source = packed.source
+
+			engine.backend.Blit(atlas.texture, &source, &Region{left, y, source.W, source.H})
+
+			left += packed.advance
+		}
+
+		y += font.Height()
+	}
+
+	atlas.texture.Tint(White())
+
+	return nil
+}
+
+// MeasureText returns the size of the text if it were drawn, without drawing
+// it: the width of it's widest line, and the height of all of it's lines.
+// Carriage returns are skipped, as they are when drawn.
+func (engine *Engine) MeasureText(font Font, text string) (width, height int32) {
+	var line int32
+
+	height = font.Height()
+
+	for _, char := range text {
+		if '\n' == char {
+			line, height = 0, height+font.Height()
+
+			continue
+		}
+
+		if '\r' == char {
+			continue
+		}
+
+		if line += font.Advance(char); line > width {
+			width = line
+		}
+	}
+
+	return width, height
+}
+
+// atlas returns the font's glyph atlas, creating it the first time the font is
+// drawn.
+func (engine *Engine) atlas(font Font) (*glyphAtlas, error) {
+	if atlas, ok := engine.atlases[font]; ok {
+		return atlas, nil
+	}
+
+	var atlas, err = createGlyphAtlas(font)
+
+	if err != nil {
+		return nil, err
+	}
+
+	engine.atlases[font] = atlas
+
+	return atlas, nil
+}
+
+// forget will destroy the font's glyph atlas, such as when the font is closed.
+func (engine *Engine) forget(font Font) {
+	if atlas, ok := engine.atlases[font]; ok {
+		atlas.destroy()
+		delete(engine.atlases, font)
+	}
+}
@@ -1,6 +1,9 @@
 package engine
 
-import "github.com/jordanbrauer/hallucinator/pkg/ecs"
+import (
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+)
 
 // Abort handles an error by checking for a nil value and panicing otherwise.
 func Abort(caught error) {
@@ -12,10 +15,10 @@ func Abort(caught error) {
 // Lerp is a linear interpolation implementation from many shader languages.
 // Used to find a given distance between two known locations (coordinates).
 //
-// The formula used here is taken from the Wikipedia article on the
-// subject: https://en.wikipedia.org/wiki/Linear_interpolation#Programming_language_support
+// See maths.Lerp, which this is a shortcut for, and the tween package to
+// interpolate values over time.
 func Lerp(a, b, distance float32) float32 {
-	return a + ((b - a) * distance)
+	return maths.Lerp(a, b, distance)
 }
 
 // Dimension will return a new dimension physics struct to define the size of a
@@ -61,9 +64,3 @@ func White() ecs.Colour {
 		Blue:  255,
 	}
 }
-
-// IsKeyPressed checks if the given SDL keyboard scancode is actively being held
-// or was pressed by the user.
-func IsKeyPressed(scancode int) bool {
-	return 0 != keyboard[scancode]
-}
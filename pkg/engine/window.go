@@ -0,0 +1,215 @@
+package engine
+
+// WindowMode is how the window is shown on the display.
+type WindowMode int
+
+// Window modes available to Display.
+const (
+	// Windowed shows a regular window with a border and title bar.
+	Windowed WindowMode = iota
+
+	// Fullscreen takes exclusive control of the display, changing it's
+	// resolution to the window's size.
+	Fullscreen
+
+	// Borderless covers the display with a window at the display's own
+	// resolution, which is quicker to switch away from than Fullscreen.
+	Borderless
+)
+
+// WindowResized is emitted when the window changes size, with it's new size.
+type WindowResized struct {
+	Width, Height int32
+}
+
+// Name of the event.
+func (WindowResized) Name() string {
+	return "window_resized"
+}
+
+// FocusGained is emitted when the window becomes the one receiving input.
+type FocusGained struct{}
+
+// Name of the event.
+func (FocusGained) Name() string {
+	return "focus_gained"
+}
+
+// FocusLost is emitted when another window starts receiving input. Every key
+// and button is released at the same time, since the window would otherwise
+// never hear of them coming back up.
+type FocusLost struct{}
+
+// Name of the event.
+func (FocusLost) Name() string {
+	return "focus_lost"
+}
+
+// WindowState is the size and focus of the window for the current frame.
+//
+// It is added to every world as a resource named "window", and the engine's
+// backend fills it in as events arrive.
+type WindowState struct {
+	width, height int32
+	focused       bool
+	resized       bool
+	refocused     bool
+}
+
+// CreateWindowState returns the state of a focused window of the given size.
+func CreateWindowState(width, height int32) *WindowState {
+	return &WindowState{width: width, height: height, focused: true}
+}
+
+// Name of the window resource.
+func (window *WindowState) Name() string {
+	return "window"
+}
+
+// Size returns the size of the window in pixels.
+func (window *WindowState) Size() (width, height int32) {
+	return window.width, window.height
+}
+
+// Focused tells if the window is the one receiving input.
+func (window *WindowState) Focused() bool {
+	return window.focused
+}
+
+// JustResized tells if the window changed size since the previous frame.
+func (window *WindowState) JustResized() bool {
+	return window.resized
+}
+
+// JustFocused tells if the window gained or lost focus since the previous
+// frame.
+func (window *WindowState) JustFocused() bool {
+	return window.refocused
+}
+
+// Resize records that the window changed to the given size. Backends call it as
+// events arrive.
+func (window *WindowState) Resize(width, height int32) {
+	if width == window.width && height == window.height {
+		return
+	}
+
+	window.width = width
+	window.height = height
+	window.resized = true
+}
+
+// Focus records that the window gained or lost focus.
+func (window *WindowState) Focus(focused bool) {
+	if focused == window.focused {
+		return
+	}
+
+	window.focused = focused
+	window.refocused = !window.refocused
+}
+
+// advance will forget the changes made during the frame.
+func (window *WindowState) advance() {
+	window.resized = false
+	window.refocused = false
+}
+
+// Window returns the size and focus of the engine's window.
+func (engine *Engine) Window() *WindowState {
+	return engine.window
+}
+
+// Resizable sets whether the user can resize the window. The pixel canvas
+// follows the window's size, unless a logical resolution is set.
+func (engine *Engine) Resizable(enabled bool) {
+	engine.resizable = enabled
+
+	engine.backend.Resizable(enabled)
+}
+
+// Display will switch the window between windowed, fullscreen, and borderless
+// fullscreen. It can be called before or after Init.
+func (engine *Engine) Display(mode WindowMode) error {
+	if err := engine.backend.Display(mode); err != nil {
+		return err
+	}
+
+	engine.mode = mode
+
+	return nil
+}
+
+// DisplayMode returns how the window is shown on the display.
+func (engine *Engine) DisplayMode() WindowMode {
+	return engine.mode
+}
+
+// Logical sets the resolution that is drawn to, no matter the size of the
+// window. Everything drawn is scaled to fit the window, with black bars along
+// the edges to keep it's aspect ratio. Pass a zero size to draw at the window's
+// own size again.
+func (engine *Engine) Logical(width, height int32) error {
+	if err := engine.backend.Logical(width, height, engine.integer); err != nil {
+		return err
+	}
+
+	engine.logicalWidth = width
+	engine.logicalHeight = height
+
+	engine.resizeCanvas()
+
+	return nil
+}
+
+// IntegerScale sets whether a logical resolution is only ever scaled by whole
+// numbers, which keeps pixel art crisp at the cost of wider black bars.
+func (engine *Engine) IntegerScale(enabled bool) error {
+	engine.integer = enabled
+
+	if 0 == engine.logicalWidth {
+		return nil
+	}
+
+	return engine.backend.Logical(engine.logicalWidth, engine.logicalHeight, enabled)
+}
+
+// Resolution returns the size that is drawn to, which is the logical
+// resolution if one is set, or else the size of the window.
+func (engine *Engine) Resolution() (width, height int32) {
+	if 0 != engine.logicalWidth && 0 != engine.logicalHeight {
+		return engine.logicalWidth, engine.logicalHeight
+	}
+
+	return engine.width, engine.height
+}
+
+// handleWindow will follow changes made to the window during the frame, and tell
+// the world about them.
+func (engine *Engine) handleWindow() {
+	if engine.window.JustResized() {
+		engine.width, engine.height = engine.window.Size()
+
+		engine.resizeCanvas()
+		engine.world.Emit(WindowResized{engine.width, engine.height})
+	}
+
+	if engine.window.JustFocused() {
+		if engine.window.Focused() {
+			engine.world.Emit(FocusGained{})
+		} else {
+			engine.input.ReleaseAll()
+			engine.world.Emit(FocusLost{})
+		}
+	}
+}
+
+// resizeCanvas will resize the pixel canvas to the resolution, if it is in use.
+// The texture it is copied to is replaced when next presented.
+func (engine *Engine) resizeCanvas() {
+	if nil == engine.texture {
+		return
+	}
+
+	engine.canvas.Resize(engine.Resolution())
+}
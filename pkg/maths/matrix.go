@@ -0,0 +1,322 @@
+package maths
+
+// Mat3 is a 3x3 matrix stored in column-major order, most often used for 2D
+// affine transformations.
+type Mat3 [9]float32
+
+// Ident3 returns the 3x3 identity matrix.
+func Ident3() Mat3 {
+	return Mat3{
+		1, 0, 0,
+		0, 1, 0,
+		0, 0, 1,
+	}
+}
+
+// Translate2D returns a matrix that moves points by the given offset.
+func Translate2D(x, y float32) Mat3 {
+	return Mat3{
+		1, 0, 0,
+		0, 1, 0,
+		x, y, 1,
+	}
+}
+
+// Rotate2D returns a matrix that rotates points counter-clockwise by the angle
+// in radians.
+func Rotate2D(radians float32) Mat3 {
+	var sin, cos = Sin(radians), Cos(radians)
+
+	return Mat3{
+		cos, sin, 0,
+		-sin, cos, 0,
+		0, 0, 1,
+	}
+}
+
+// Scale2D returns a matrix that scales points by the given factors.
+func Scale2D(x, y float32) Mat3 {
+	return Mat3{
+		x, 0, 0,
+		0, y, 0,
+		0, 0, 1,
+	}
+}
+
+// At returns the value in the given row and column.
+func (left Mat3) At(row, column int) float32 {
+	return left[(column*3)+row]
+}
+
+// Multiply returns the product of both matrices, applying right first.
+func (left Mat3) Multiply(right Mat3) Mat3 {
+	var product Mat3
+
+	for column := 0; column < 3; column++ {
+		for row := 0; row < 3; row++ {
+			var sum float32
+
+			for k := 0; k < 3; k++ {
+				sum += left[(k*3)+row] * right[(column*3)+k]
+			}
+
+			product[(column*3)+row] = sum
+		}
+	}
+
+	return product
+}
+
+// MultiplyVec3 returns the vector transformed by the matrix.
+func (left Mat3) MultiplyVec3(right Vec3) Vec3 {
+	return Vec3{
+		(left[0] * right.X) + (left[3] * right.Y) + (left[6] * right.Z),
+		(left[1] * right.X) + (left[4] * right.Y) + (left[7] * right.Z),
+		(left[2] * right.X) + (left[5] * right.Y) + (left[8] * right.Z),
+	}
+}
+
+// TransformPoint returns the 2D point transformed by the matrix, including
+// any translation.
+func (left Mat3) TransformPoint(point Vec2) Vec2 {
+	return left.MultiplyVec3(point.Vec3(1)).Vec2()
+}
+
+// Transpose returns the matrix flipped over it's diagonal.
+func (left Mat3) Transpose() Mat3 {
+	return Mat3{
+		left[0], left[3], left[6],
+		left[1], left[4], left[7],
+		left[2], left[5], left[8],
+	}
+}
+
+// Determinant returns the determinant of the matrix.
+func (left Mat3) Determinant() float32 {
+	return (left[0] * ((left[4] * left[8]) - (left[7] * left[5]))) -
+		(left[3] * ((left[1] * left[8]) - (left[7] * left[2]))) +
+		(left[6] * ((left[1] * left[5]) - (left[4] * left[2])))
+}
+
+// Inverse returns the matrix that undoes this one, or the zero matrix if the
+// matrix cannot be inverted.
+func (left Mat3) Inverse() Mat3 {
+	var determinant = left.Determinant()
+
+	if 0 == determinant {
+		return Mat3{}
+	}
+
+	var inverse = 1 / determinant
+
+	return Mat3{
+		((left[4] * left[8]) - (left[5] * left[7])) * inverse,
+		((left[2] * left[7]) - (left[1] * left[8])) * inverse,
+		((left[1] * left[5]) - (left[2] * left[4])) * inverse,
+		((left[5] * left[6]) - (left[3] * left[8])) * inverse,
+		((left[0] * left[8]) - (left[2] * left[6])) * inverse,
+		((left[2] * left[3]) - (left[0] * left[5])) * inverse,
+		((left[3] * left[7]) - (left[4] * left[6])) * inverse,
+		((left[1] * left[6]) - (left[0] * left[7])) * inverse,
+		((left[0] * left[4]) - (left[1] * left[3])) * inverse,
+	}
+}
+
+// Mat4 is a 4x4 matrix stored in column-major order, most often used for 3D
+// affine transformations and projections.
+type Mat4 [16]float32
+
+// Ident4 returns the 4x4 identity matrix.
+func Ident4() Mat4 {
+	return Mat4{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Translate3D returns a matrix that moves points by the given offset.
+func Translate3D(x, y, z float32) Mat4 {
+	return Mat4{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		x, y, z, 1,
+	}
+}
+
+// Scale3D returns a matrix that scales points by the given factors.
+func Scale3D(x, y, z float32) Mat4 {
+	return Mat4{
+		x, 0, 0, 0,
+		0, y, 0, 0,
+		0, 0, z, 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Rotate3D returns a matrix that rotates points by the angle in radians around
+// the given axis.
+func Rotate3D(radians float32, axis Vec3) Mat4 {
+	return QuatRotate(radians, axis).Mat4()
+}
+
+// Ortho returns an orthographic projection of the given clipping planes.
+func Ortho(left, right, bottom, top, near, far float32) Mat4 {
+	var width, height, depth = right - left, top - bottom, far - near
+
+	return Mat4{
+		2 / width, 0, 0, 0,
+		0, 2 / height, 0, 0,
+		0, 0, -2 / depth, 0,
+		-(right + left) / width, -(top + bottom) / height, -(far + near) / depth, 1,
+	}
+}
+
+// Perspective returns a perspective projection with the given vertical field
+// of view in radians, aspect ratio, and clipping planes.
+func Perspective(fovy, aspect, near, far float32) Mat4 {
+	var focal = 1 / Tan(fovy/2)
+	var depth = near - far
+
+	return Mat4{
+		focal / aspect, 0, 0, 0,
+		0, focal, 0, 0,
+		0, 0, (far + near) / depth, -1,
+		0, 0, (2 * far * near) / depth, 0,
+	}
+}
+
+// LookAt returns a view matrix for a camera at the eye, facing the centre,
+// with the given up direction.
+func LookAt(eye, centre, up Vec3) Mat4 {
+	var forward = centre.Subtract(eye).Normalize()
+	var side = forward.Cross(up.Normalize()).Normalize()
+	var upward = side.Cross(forward)
+
+	return Mat4{
+		side.X, upward.X, -forward.X, 0,
+		side.Y, upward.Y, -forward.Y, 0,
+		side.Z, upward.Z, -forward.Z, 0,
+		-side.Dot(eye), -upward.Dot(eye), forward.Dot(eye), 1,
+	}
+}
+
+// At returns the value in the given row and column.
+func (left Mat4) At(row, column int) float32 {
+	return left[(column*4)+row]
+}
+
+// Multiply returns the product of both matrices, applying right first.
+func (left Mat4) Multiply(right Mat4) Mat4 {
+	var product Mat4
+
+	for column := 0; column < 4; column++ {
+		for row := 0; row < 4; row++ {
+			var sum float32
+
+			for k := 0; k < 4; k++ {
+				sum += left[(k*4)+row] * right[(column*4)+k]
+			}
+
+			product[(column*4)+row] = sum
+		}
+	}
+
+	return product
+}
+
+// MultiplyVec4 returns the vector transformed by the matrix.
+func (left Mat4) MultiplyVec4(right Vec4) Vec4 {
+	return Vec4{
+		(left[0] * right.X) + (left[4] * right.Y) + (left[8] * right.Z) + (left[12] * right.W),
+		(left[1] * right.X) + (left[5] * right.Y) + (left[9] * right.Z) + (left[13] * right.W),
+		(left[2] * right.X) + (left[6] * right.Y) + (left[10] * right.Z) + (left[14] * right.W),
+		(left[3] * right.X) + (left[7] * right.Y) + (left[11] * right.Z) + (left[15] * right.W),
+	}
+}
+
+// TransformPoint returns the 3D point transformed by the matrix, including any
+// translation and perspective division.
+func (left Mat4) TransformPoint(point Vec3) Vec3 {
+	var result = left.MultiplyVec4(point.Vec4(1))
+
+	if 0 != result.W && 1 != result.W {
+		return result.Vec3().Divide(result.W)
+	}
+
+	return result.Vec3()
+}
+
+// Transpose returns the matrix flipped over it's diagonal.
+func (left Mat4) Transpose() Mat4 {
+	return Mat4{
+		left[0], left[4], left[8], left[12],
+		left[1], left[5], left[9], left[13],
+		left[2], left[6], left[10], left[14],
+		left[3], left[7], left[11], left[15],
+	}
+}
+
+// Determinant returns the determinant of the matrix.
+func (left Mat4) Determinant() float32 {
+	var minors = left.minors()
+
+	return (minors[0] * minors[11]) - (minors[1] * minors[10]) + (minors[2] * minors[9]) +
+		(minors[3] * minors[8]) - (minors[4] * minors[7]) + (minors[5] * minors[6])
+}
+
+// Inverse returns the matrix that undoes this one, or the zero matrix if the
+// matrix cannot be inverted.
+func (left Mat4) Inverse() Mat4 {
+	var b = left.minors()
+	var determinant = (b[0] * b[11]) - (b[1] * b[10]) + (b[2] * b[9]) +
+		(b[3] * b[8]) - (b[4] * b[7]) + (b[5] * b[6])
+
+	if 0 == determinant {
+		return Mat4{}
+	}
+
+	var inverse = 1 / determinant
+	var a = left
+
+	return Mat4{
+		((a[5] * b[11]) - (a[6] * b[10]) + (a[7] * b[9])) * inverse,
+		((a[2] * b[10]) - (a[1] * b[11]) - (a[3] * b[9])) * inverse,
+		((a[13] * b[5]) - (a[14] * b[4]) + (a[15] * b[3])) * inverse,
+		((a[10] * b[4]) - (a[9] * b[5]) - (a[11] * b[3])) * inverse,
+		((a[6] * b[8]) - (a[4] * b[11]) - (a[7] * b[7])) * inverse,
+		((a[0] * b[11]) - (a[2] * b[8]) + (a[3] * b[7])) * inverse,
+		((a[14] * b[2]) - (a[12] * b[5]) - (a[15] * b[1])) * inverse,
+		((a[8] * b[5]) - (a[10] * b[2]) + (a[11] * b[1])) * inverse,
+		((a[4] * b[10]) - (a[5] * b[8]) + (a[7] * b[6])) * inverse,
+		((a[1] * b[8]) - (a[0] * b[10]) - (a[3] * b[6])) * inverse,
+		((a[12] * b[4]) - (a[13] * b[2]) + (a[15] * b[0])) * inverse,
+		((a[9] * b[2]) - (a[8] * b[4]) - (a[11] * b[0])) * inverse,
+		((a[5] * b[7]) - (a[4] * b[9]) - (a[6] * b[6])) * inverse,
+		((a[0] * b[9]) - (a[1] * b[7]) + (a[2] * b[6])) * inverse,
+		((a[13] * b[1]) - (a[12] * b[3]) - (a[14] * b[0])) * inverse,
+		((a[8] * b[3]) - (a[9] * b[1]) + (a[10] * b[0])) * inverse,
+	}
+}
+
+// minors are the 2x2 determinants shared by the determinant and inverse.
+func (left Mat4) minors() [12]float32 {
+	var a = left
+
+	return [12]float32{
+		(a[0] * a[5]) - (a[1] * a[4]),
+		(a[0] * a[6]) - (a[2] * a[4]),
+		(a[0] * a[7]) - (a[3] * a[4]),
+		(a[1] * a[6]) - (a[2] * a[5]),
+		(a[1] * a[7]) - (a[3] * a[5]),
+		(a[2] * a[7]) - (a[3] * a[6]),
+		(a[8] * a[13]) - (a[9] * a[12]),
+		(a[8] * a[14]) - (a[10] * a[12]),
+		(a[8] * a[15]) - (a[11] * a[12]),
+		(a[9] * a[14]) - (a[10] * a[13]),
+		(a[9] * a[15]) - (a[11] * a[13]),
+		(a[10] * a[15]) - (a[11] * a[14]),
+	}
+}
@@ -0,0 +1,127 @@
+package maths
+
+// Quat is a quaternion representing a rotation in three dimensions, made of a
+// scalar part W and a vector part V.
+type Quat struct {
+	W float32
+	V Vec3
+}
+
+// QuatIdent returns the quaternion that does not rotate.
+func QuatIdent() Quat {
+	return Quat{W: 1}
+}
+
+// QuatRotate returns a quaternion rotating by the angle in radians around the
+// given axis.
+func QuatRotate(radians float32, axis Vec3) Quat {
+	var half = radians / 2
+
+	return Quat{Cos(half), axis.Normalize().Scale(Sin(half))}
+}
+
+// QuatEuler returns a quaternion rotating by the given angles in radians
+// around the x axis, then the y axis, then the z axis.
+func QuatEuler(x, y, z float32) Quat {
+	return QuatRotate(z, Vec3{Z: 1}).
+		Multiply(QuatRotate(y, Vec3{Y: 1})).
+		Multiply(QuatRotate(x, Vec3{X: 1}))
+}
+
+// Multiply returns the rotation of right followed by the rotation of left.
+func (left Quat) Multiply(right Quat) Quat {
+	return Quat{
+		(left.W * right.W) - left.V.Dot(right.V),
+		right.V.Scale(left.W).Add(left.V.Scale(right.W)).Add(left.V.Cross(right.V)),
+	}
+}
+
+// Conjugate returns the quaternion with it's vector part negated.
+func (left Quat) Conjugate() Quat {
+	return Quat{left.W, left.V.Negate()}
+}
+
+// Length returns the magnitude of the quaternion.
+func (left Quat) Length() float32 {
+	return Sqrt((left.W * left.W) + left.V.LengthSquared())
+}
+
+// Normalize returns a quaternion of length one, or the identity if the
+// quaternion has no length.
+func (left Quat) Normalize() Quat {
+	var length = left.Length()
+
+	if 0 == length {
+		return QuatIdent()
+	}
+
+	return Quat{left.W / length, left.V.Divide(length)}
+}
+
+// Inverse returns the quaternion that undoes this rotation.
+func (left Quat) Inverse() Quat {
+	var squared = (left.W * left.W) + left.V.LengthSquared()
+
+	if 0 == squared {
+		return QuatIdent()
+	}
+
+	var conjugate = left.Conjugate()
+
+	return Quat{conjugate.W / squared, conjugate.V.Divide(squared)}
+}
+
+// Dot returns the dot product of both quaternions.
+func (left Quat) Dot(right Quat) float32 {
+	return (left.W * right.W) + left.V.Dot(right.V)
+}
+
+// Rotate returns the vector rotated by the quaternion, which must be of unit
+// length.
+func (left Quat) Rotate(vector Vec3) Vec3 {
+	var twice = left.V.Cross(vector).Add(vector.Scale(left.W))
+
+	return vector.Add(left.V.Cross(twice).Scale(2))
+}
+
+// Mat4 returns the rotation matrix of the quaternion, which must be of unit
+// length.
+func (left Quat) Mat4() Mat4 {
+	var w, x, y, z = left.W, left.V.X, left.V.Y, left.V.Z
+
+	return Mat4{
+		1 - (2 * ((y * y) + (z * z))), 2 * ((x * y) + (w * z)), 2 * ((x * z) - (w * y)), 0,
+		2 * ((x * y) - (w * z)), 1 - (2 * ((x * x) + (z * z))), 2 * ((y * z) + (w * x)), 0,
+		2 * ((x * z) + (w * y)), 2 * ((y * z) - (w * x)), 1 - (2 * ((x * x) + (y * y))), 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Slerp returns the rotation spherically interpolated towards right by the
+// given distance, taking the shortest path.
+func (left Quat) Slerp(right Quat, distance float32) Quat {
+	var cos = left.Dot(right)
+
+	if cos < 0 {
+		right = Quat{-right.W, right.V.Negate()}
+		cos = -cos
+	}
+
+	// nearly parallel rotations fall back to a linear interpolation
+	if cos > (1 - Epsilon) {
+		return Quat{
+			Lerp(left.W, right.W, distance),
+			left.V.Lerp(right.V, distance),
+		}.Normalize()
+	}
+
+	var angle = Acos(cos)
+	var sin = Sin(angle)
+	var from = Sin((1-distance)*angle) / sin
+	var to = Sin(distance*angle) / sin
+
+	return Quat{
+		(left.W * from) + (right.W * to),
+		left.V.Scale(from).Add(right.V.Scale(to)),
+	}
+}
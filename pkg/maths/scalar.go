@@ -0,0 +1,108 @@
+// Package maths provides value types for 2D and 3D vectors, matrices,
+// quaternions, and shapes, along with the intersection tests between them.
+//
+// Every type is a plain value, and every operation returns a new value instead
+// of mutating its receiver, so results can be chained freely.
+package maths
+
+import "math"
+
+// Pi is the ratio of a circle's circumference to its diameter, as a float32.
+const Pi = float32(math.Pi)
+
+// Epsilon is the tolerance used when comparing floating point values.
+const Epsilon float32 = 1e-6
+
+// Lerp is a linear interpolation implementation from many shader languages.
+// Used to find a given distance between two known locations (coordinates).
+//
+// The formula used here is taken from the Wikipedia article on the
+// subject: https://en.wikipedia.org/wiki/Linear_interpolation#Programming_language_support
+func Lerp(a, b, distance float32) float32 {
+	return a + ((b - a) * distance)
+}
+
+// Clamp restricts the value to the inclusive range between min and max.
+func Clamp(value, min, max float32) float32 {
+	if value < min {
+		return min
+	}
+
+	if value > max {
+		return max
+	}
+
+	return value
+}
+
+// Radians converts an angle in degrees to radians.
+func Radians(degrees float32) float32 {
+	return degrees * (Pi / 180)
+}
+
+// Degrees converts an angle in radians to degrees.
+func Degrees(radians float32) float32 {
+	return radians * (180 / Pi)
+}
+
+// Equal tells if two values are within Epsilon of one another.
+func Equal(a, b float32) bool {
+	return Abs(a-b) <= Epsilon
+}
+
+// Abs returns the absolute value of the given value.
+func Abs(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
+
+// Sqrt returns the square root of the given value.
+func Sqrt(value float32) float32 {
+	return float32(math.Sqrt(float64(value)))
+}
+
+// Sin returns the sine of the given angle in radians.
+func Sin(radians float32) float32 {
+	return float32(math.Sin(float64(radians)))
+}
+
+// Cos returns the cosine of the given angle in radians.
+func Cos(radians float32) float32 {
+	return float32(math.Cos(float64(radians)))
+}
+
+// Tan returns the tangent of the given angle in radians.
+func Tan(radians float32) float32 {
+	return float32(math.Tan(float64(radians)))
+}
+
+// Acos returns the angle in radians whose cosine is the given value.
+func Acos(value float32) float32 {
+	return float32(math.Acos(float64(Clamp(value, -1, 1))))
+}
+
+// Atan2 returns the angle in radians of the point y, x from the origin.
+func Atan2(y, x float32) float32 {
+	return float32(math.Atan2(float64(y), float64(x)))
+}
+
+// Min returns the smaller of the two values.
+func Min(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// Max returns the larger of the two values.
+func Max(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
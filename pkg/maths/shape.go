@@ -0,0 +1,138 @@
+package maths
+
+// Rect is an axis-aligned rectangle starting at it's top left corner.
+type Rect struct {
+	X, Y, Width, Height float32
+}
+
+// Min returns the top left corner of the rectangle.
+func (rect Rect) Min() Vec2 {
+	return Vec2{rect.X, rect.Y}
+}
+
+// Max returns the bottom right corner of the rectangle.
+func (rect Rect) Max() Vec2 {
+	return Vec2{rect.X + rect.Width, rect.Y + rect.Height}
+}
+
+// Centre returns the point in the middle of the rectangle.
+func (rect Rect) Centre() Vec2 {
+	return Vec2{rect.X + (rect.Width / 2), rect.Y + (rect.Height / 2)}
+}
+
+// Contains tells if the point is inside of, or on the edge of, the rectangle.
+func (rect Rect) Contains(point Vec2) bool {
+	return point.X >= rect.X &&
+		point.X <= (rect.X+rect.Width) &&
+		point.Y >= rect.Y &&
+		point.Y <= (rect.Y+rect.Height)
+}
+
+// Intersects tells if both rectangles overlap. Rectangles that only touch at
+// their edges are considered to overlap.
+func (rect Rect) Intersects(other Rect) bool {
+	return rect.X <= (other.X+other.Width) &&
+		other.X <= (rect.X+rect.Width) &&
+		rect.Y <= (other.Y+other.Height) &&
+		other.Y <= (rect.Y+rect.Height)
+}
+
+// Intersection returns the area shared by both rectangles, and false if they
+// do not overlap.
+func (rect Rect) Intersection(other Rect) (Rect, bool) {
+	if !rect.Intersects(other) {
+		return Rect{}, false
+	}
+
+	var min = Vec2{Max(rect.X, other.X), Max(rect.Y, other.Y)}
+	var max = Vec2{Min(rect.X+rect.Width, other.X+other.Width), Min(rect.Y+rect.Height, other.Y+other.Height)}
+
+	return Rect{min.X, min.Y, max.X - min.X, max.Y - min.Y}, true
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+func (rect Rect) Union(other Rect) Rect {
+	var min = Vec2{Min(rect.X, other.X), Min(rect.Y, other.Y)}
+	var max = Vec2{Max(rect.X+rect.Width, other.X+other.Width), Max(rect.Y+rect.Height, other.Y+other.Height)}
+
+	return Rect{min.X, min.Y, max.X - min.X, max.Y - min.Y}
+}
+
+// Closest returns the point inside of the rectangle nearest to the given one.
+func (rect Rect) Closest(point Vec2) Vec2 {
+	return Vec2{
+		Clamp(point.X, rect.X, rect.X+rect.Width),
+		Clamp(point.Y, rect.Y, rect.Y+rect.Height),
+	}
+}
+
+// IntersectsCircle tells if the rectangle and circle overlap.
+func (rect Rect) IntersectsCircle(circle Circle) bool {
+	return circle.Contains(rect.Closest(circle.Centre))
+}
+
+// Circle is a round shape around it's centre.
+type Circle struct {
+	Centre Vec2
+	Radius float32
+}
+
+// Bounds returns the smallest rectangle containing the circle.
+func (circle Circle) Bounds() Rect {
+	return Rect{
+		circle.Centre.X - circle.Radius,
+		circle.Centre.Y - circle.Radius,
+		circle.Radius * 2,
+		circle.Radius * 2,
+	}
+}
+
+// Contains tells if the point is inside of, or on the edge of, the circle.
+func (circle Circle) Contains(point Vec2) bool {
+	return point.Subtract(circle.Centre).LengthSquared() <= (circle.Radius * circle.Radius)
+}
+
+// Intersects tells if both circles overlap.
+func (circle Circle) Intersects(other Circle) bool {
+	var reach = circle.Radius + other.Radius
+
+	return circle.Centre.Subtract(other.Centre).LengthSquared() <= (reach * reach)
+}
+
+// AABB is an axis-aligned bounding box in three dimensions.
+type AABB struct {
+	Min, Max Vec3
+}
+
+// Size returns the width, height, and depth of the box.
+func (box AABB) Size() Vec3 {
+	return box.Max.Subtract(box.Min)
+}
+
+// Centre returns the point in the middle of the box.
+func (box AABB) Centre() Vec3 {
+	return box.Min.Add(box.Max).Scale(0.5)
+}
+
+// Contains tells if the point is inside of, or on the surface of, the box.
+func (box AABB) Contains(point Vec3) bool {
+	return point.X >= box.Min.X && point.X <= box.Max.X &&
+		point.Y >= box.Min.Y && point.Y <= box.Max.Y &&
+		point.Z >= box.Min.Z && point.Z <= box.Max.Z
+}
+
+// Intersects tells if both boxes overlap. Boxes that only touch at their
+// surfaces are considered to overlap.
+func (box AABB) Intersects(other AABB) bool {
+	return box.Min.X <= other.Max.X && other.Min.X <= box.Max.X &&
+		box.Min.Y <= other.Max.Y && other.Min.Y <= box.Max.Y &&
+		box.Min.Z <= other.Max.Z && other.Min.Z <= box.Max.Z
+}
+
+// Union returns the smallest box containing both boxes.
+func (box AABB) Union(other AABB) AABB {
+	return AABB{
+		Vec3{Min(box.Min.X, other.Min.X), Min(box.Min.Y, other.Min.Y), Min(box.Min.Z, other.Min.Z)},
+		Vec3{Max(box.Max.X, other.Max.X), Max(box.Max.Y, other.Max.Y), Max(box.Max.Z, other.Max.Z)},
+	}
+}
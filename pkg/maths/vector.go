@@ -0,0 +1,279 @@
+package maths
+
+// Vec2 is a two dimensional vector.
+type Vec2 struct {
+	X, Y float32
+}
+
+// Add returns the sum of both vectors.
+func (left Vec2) Add(right Vec2) Vec2 {
+	return Vec2{left.X + right.X, left.Y + right.Y}
+}
+
+// Subtract returns the difference of both vectors.
+func (left Vec2) Subtract(right Vec2) Vec2 {
+	return Vec2{left.X - right.X, left.Y - right.Y}
+}
+
+// Multiply returns the component-wise product of both vectors.
+func (left Vec2) Multiply(right Vec2) Vec2 {
+	return Vec2{left.X * right.X, left.Y * right.Y}
+}
+
+// Scale returns the vector with every component multiplied by the scalar.
+func (left Vec2) Scale(scalar float32) Vec2 {
+	return Vec2{left.X * scalar, left.Y * scalar}
+}
+
+// Divide returns the vector with every component divided by the scalar.
+func (left Vec2) Divide(scalar float32) Vec2 {
+	return Vec2{left.X / scalar, left.Y / scalar}
+}
+
+// Negate returns the vector pointing in the opposite direction.
+func (left Vec2) Negate() Vec2 {
+	return Vec2{-left.X, -left.Y}
+}
+
+// Dot returns the dot product of both vectors.
+func (left Vec2) Dot(right Vec2) float32 {
+	return (left.X * right.X) + (left.Y * right.Y)
+}
+
+// Cross returns the z component of the cross product of both vectors, which is
+// positive when right is counter-clockwise from left.
+func (left Vec2) Cross(right Vec2) float32 {
+	return (left.X * right.Y) - (left.Y * right.X)
+}
+
+// LengthSquared returns the squared length of the vector, which avoids a
+// square root when only comparing lengths.
+func (left Vec2) LengthSquared() float32 {
+	return left.Dot(left)
+}
+
+// Length returns the magnitude of the vector.
+func (left Vec2) Length() float32 {
+	return Sqrt(left.LengthSquared())
+}
+
+// Distance returns the distance between the points of both vectors.
+func (left Vec2) Distance(right Vec2) float32 {
+	return left.Subtract(right).Length()
+}
+
+// Normalize returns a vector of length one in the same direction, or the zero
+// vector if the vector has no length.
+func (left Vec2) Normalize() Vec2 {
+	var length = left.Length()
+
+	if 0 == length {
+		return Vec2{}
+	}
+
+	return left.Divide(length)
+}
+
+// Rotate returns the vector rotated counter-clockwise by the angle in radians.
+func (left Vec2) Rotate(radians float32) Vec2 {
+	var sin, cos = Sin(radians), Cos(radians)
+
+	return Vec2{
+		(left.X * cos) - (left.Y * sin),
+		(left.X * sin) + (left.Y * cos),
+	}
+}
+
+// Angle returns the angle in radians of the vector from the positive x axis.
+func (left Vec2) Angle() float32 {
+	return Atan2(left.Y, left.X)
+}
+
+// Lerp returns the vector interpolated towards right by the given distance.
+func (left Vec2) Lerp(right Vec2, distance float32) Vec2 {
+	return Vec2{Lerp(left.X, right.X, distance), Lerp(left.Y, right.Y, distance)}
+}
+
+// Vec3 extends the vector into three dimensions with the given z component.
+func (left Vec2) Vec3(z float32) Vec3 {
+	return Vec3{left.X, left.Y, z}
+}
+
+// Vec3 is a three dimensional vector.
+type Vec3 struct {
+	X, Y, Z float32
+}
+
+// Add returns the sum of both vectors.
+func (left Vec3) Add(right Vec3) Vec3 {
+	return Vec3{left.X + right.X, left.Y + right.Y, left.Z + right.Z}
+}
+
+// Subtract returns the difference of both vectors.
+func (left Vec3) Subtract(right Vec3) Vec3 {
+	return Vec3{left.X - right.X, left.Y - right.Y, left.Z - right.Z}
+}
+
+// Multiply returns the component-wise product of both vectors.
+func (left Vec3) Multiply(right Vec3) Vec3 {
+	return Vec3{left.X * right.X, left.Y * right.Y, left.Z * right.Z}
+}
+
+// Scale returns the vector with every component multiplied by the scalar.
+func (left Vec3) Scale(scalar float32) Vec3 {
+	return Vec3{left.X * scalar, left.Y * scalar, left.Z * scalar}
+}
+
+// Divide returns the vector with every component divided by the scalar.
+func (left Vec3) Divide(scalar float32) Vec3 {
+	return Vec3{left.X / scalar, left.Y / scalar, left.Z / scalar}
+}
+
+// Negate returns the vector pointing in the opposite direction.
+func (left Vec3) Negate() Vec3 {
+	return Vec3{-left.X, -left.Y, -left.Z}
+}
+
+// Dot returns the dot product of both vectors.
+func (left Vec3) Dot(right Vec3) float32 {
+	return (left.X * right.X) + (left.Y * right.Y) + (left.Z * right.Z)
+}
+
+// Cross returns a vector perpendicular to both vectors.
+func (left Vec3) Cross(right Vec3) Vec3 {
+	return Vec3{
+		(left.Y * right.Z) - (left.Z * right.Y),
+		(left.Z * right.X) - (left.X * right.Z),
+		(left.X * right.Y) - (left.Y * right.X),
+	}
+}
+
+// LengthSquared returns the squared length of the vector, which avoids a
+// square root when only comparing lengths.
+func (left Vec3) LengthSquared() float32 {
+	return left.Dot(left)
+}
+
+// Length returns the magnitude of the vector.
+func (left Vec3) Length() float32 {
+	return Sqrt(left.LengthSquared())
+}
+
+// Distance returns the distance between the points of both vectors.
+func (left Vec3) Distance(right Vec3) float32 {
+	return left.Subtract(right).Length()
+}
+
+// Normalize returns a vector of length one in the same direction, or the zero
+// vector if the vector has no length.
+func (left Vec3) Normalize() Vec3 {
+	var length = left.Length()
+
+	if 0 == length {
+		return Vec3{}
+	}
+
+	return left.Divide(length)
+}
+
+// Rotate returns the vector rotated by the given quaternion.
+func (left Vec3) Rotate(rotation Quat) Vec3 {
+	return rotation.Rotate(left)
+}
+
+// Lerp returns the vector interpolated towards right by the given distance.
+func (left Vec3) Lerp(right Vec3, distance float32) Vec3 {
+	return Vec3{
+		Lerp(left.X, right.X, distance),
+		Lerp(left.Y, right.Y, distance),
+		Lerp(left.Z, right.Z, distance),
+	}
+}
+
+// Vec2 drops the z component of the vector.
+func (left Vec3) Vec2() Vec2 {
+	return Vec2{left.X, left.Y}
+}
+
+// Vec4 extends the vector into four dimensions with the given w component.
+func (left Vec3) Vec4(w float32) Vec4 {
+	return Vec4{left.X, left.Y, left.Z, w}
+}
+
+// Vec4 is a four dimensional vector, most often a homogeneous coordinate.
+type Vec4 struct {
+	X, Y, Z, W float32
+}
+
+// Add returns the sum of both vectors.
+func (left Vec4) Add(right Vec4) Vec4 {
+	return Vec4{left.X + right.X, left.Y + right.Y, left.Z + right.Z, left.W + right.W}
+}
+
+// Subtract returns the difference of both vectors.
+func (left Vec4) Subtract(right Vec4) Vec4 {
+	return Vec4{left.X - right.X, left.Y - right.Y, left.Z - right.Z, left.W - right.W}
+}
+
+// Multiply returns the component-wise product of both vectors.
+func (left Vec4) Multiply(right Vec4) Vec4 {
+	return Vec4{left.X * right.X, left.Y * right.Y, left.Z * right.Z, left.W * right.W}
+}
+
+// Scale returns the vector with every component multiplied by the scalar.
+func (left Vec4) Scale(scalar float32) Vec4 {
+	return Vec4{left.X * scalar, left.Y * scalar, left.Z * scalar, left.W * scalar}
+}
+
+// Divide returns the vector with every component divided by the scalar.
+func (left Vec4) Divide(scalar float32) Vec4 {
+	return Vec4{left.X / scalar, left.Y / scalar, left.Z / scalar, left.W / scalar}
+}
+
+// Negate returns the vector pointing in the opposite direction.
+func (left Vec4) Negate() Vec4 {
+	return Vec4{-left.X, -left.Y, -left.Z, -left.W}
+}
+
+// Dot returns the dot product of both vectors.
+func (left Vec4) Dot(right Vec4) float32 {
+	return (left.X * right.X) + (left.Y * right.Y) + (left.Z * right.Z) + (left.W * right.W)
+}
+
+// LengthSquared returns the squared length of the vector, which avoids a
+// square root when only comparing lengths.
+func (left Vec4) LengthSquared() float32 {
+	return left.Dot(left)
+}
+
+// Length returns the magnitude of the vector.
+func (left Vec4) Length() float32 {
+	return Sqrt(left.LengthSquared())
+}
+
+// Normalize returns a vector of length one in the same direction, or the zero
+// vector if the vector has no length.
+func (left Vec4) Normalize() Vec4 {
+	var length = left.Length()
+
+	if 0 == length {
+		return Vec4{}
+	}
+
+	return left.Divide(length)
+}
+
+// Lerp returns the vector interpolated towards right by the given distance.
+func (left Vec4) Lerp(right Vec4, distance float32) Vec4 {
+	return Vec4{
+		Lerp(left.X, right.X, distance),
+		Lerp(left.Y, right.Y, distance),
+		Lerp(left.Z, right.Z, distance),
+		Lerp(left.W, right.W, distance),
+	}
+}
+
+// Vec3 drops the w component of the vector.
+func (left Vec4) Vec3() Vec3 {
+	return Vec3{left.X, left.Y, left.Z}
+}
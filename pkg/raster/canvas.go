@@ -0,0 +1,211 @@
+// Package raster draws pixels, lines, and shapes to an image in memory using
+// only Go, so that frames and charts can be rendered without a window or SDL.
+package raster
+
+import (
+	"image"
+	"image/png"
+	"io"
+	"os"
+
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+)
+
+// Canvas draws to an RGBA image with the current fill and stroke colours.
+type Canvas struct {
+	image  *image.RGBA
+	fill   ecs.Colour
+	stroke ecs.Colour
+}
+
+// CreateCanvas returns a new canvas of the given size, cleared to black, that
+// draws in white.
+func CreateCanvas(width, height int32) *Canvas {
+	return Wrap(image.NewRGBA(image.Rect(0, 0, int(width), int(height))))
+}
+
+// Wrap returns a canvas that draws to an existing image.
+func Wrap(target *image.RGBA) *Canvas {
+	var white = ecs.Colour{Red: 255, Green: 255, Blue: 255}
+	var canvas = &Canvas{image: target, fill: white, stroke: white}
+
+	canvas.Clear()
+
+	return canvas
+}
+
+// Image returns the image being drawn to.
+func (canvas *Canvas) Image() *image.RGBA {
+	return canvas.image
+}
+
+// Pixels returns the pixels of the image, four bytes per pixel in RGBA order,
+// row by row from the top left.
+func (canvas *Canvas) Pixels() []byte {
+	return canvas.image.Pix
+}
+
+// Size returns the width and height of the canvas in pixels.
+func (canvas *Canvas) Size() (int32, int32) {
+	var bounds = canvas.image.Bounds()
+
+	return int32(bounds.Dx()), int32(bounds.Dy())
+}
+
+// Resize will replace the image with a new one of the given size, cleared to
+// black. The fill and stroke colours are kept.
+func (canvas *Canvas) Resize(width, height int32) {
+	canvas.image = image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+
+	canvas.Clear()
+}
+
+// Fill sets the colour to be used for drawing solid shapes.
+func (canvas *Canvas) Fill(colour ecs.Colour) {
+	canvas.fill = colour
+}
+
+// Stroke sets the colour to be used for drawing lines and the outlines of
+// shapes.
+func (canvas *Canvas) Stroke(colour ecs.Colour) {
+	canvas.stroke = colour
+}
+
+// Clear will set every pixel to opaque black.
+func (canvas *Canvas) Clear() {
+	var pixels = canvas.image.Pix
+
+	for i := 0; i < len(pixels); i += 4 {
+		pixels[i] = 0
+		pixels[i+1] = 0
+		pixels[i+2] = 0
+		pixels[i+3] = 255
+	}
+}
+
+// Draw will colour a single pixel. Pixels outside of the canvas are ignored.
+func (canvas *Canvas) Draw(x, y int32, colour ecs.Colour) {
+	if !image.Pt(int(x), int(y)).In(canvas.image.Rect) {
+		return
+	}
+
+	var index = canvas.image.PixOffset(int(x), int(y))
+	var pixels = canvas.image.Pix
+
+	pixels[index] = colour.Red
+	pixels[index+1] = colour.Green
+	pixels[index+2] = colour.Blue
+	pixels[index+3] = 255
+}
+
+// Line will plot a single pixel wide line from an x, y origin to an x, y
+// destination with the stroke colour, using Bresenham's algorithm.
+func (canvas *Canvas) Line(origin, destination ecs.Position) {
+	var x, y = int32(origin.X), int32(origin.Y)
+	var xDestination, yDestination = int32(destination.X), int32(destination.Y)
+	var dx, dy = abs(xDestination - x), -abs(yDestination - y)
+	var xStep, yStep int32 = 1, 1
+	var err = dx + dy
+
+	if x > xDestination {
+		xStep = -1
+	}
+
+	if y > yDestination {
+		yStep = -1
+	}
+
+	for {
+		canvas.Draw(x, y, canvas.stroke)
+
+		if x == xDestination && y == yDestination {
+			return
+		}
+
+		var twice = err * 2
+
+		if twice >= dy {
+			err += dy
+			x += xStep
+		}
+
+		if twice <= dx {
+			err += dx
+			y += yStep
+		}
+	}
+}
+
+// Rect will draw a solid rectangle of the given size at the given x, y
+// coordinates with the fill colour.
+//
+// Drawing of the rectangle will begin the top left corner of the rectangle.
+func (canvas *Canvas) Rect(position ecs.Position, dimensions ecs.Dimensions) {
+	var area = image.Rect(
+		int(position.X),
+		int(position.Y),
+		int(position.X)+int(dimensions.Width),
+		int(position.Y)+int(dimensions.Height),
+	).Intersect(canvas.image.Rect)
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			canvas.Draw(int32(x), int32(y), canvas.fill)
+		}
+	}
+}
+
+// Square will draw a square of the given size to the given position.
+func (canvas *Canvas) Square(position ecs.Position, dimensions ecs.Dimensions) {
+	canvas.Rect(position, dimensions)
+}
+
+// Ellipse will draw a solid ellipse of the given size centred on the given x,
+// y coordinates with the fill colour.
+func (canvas *Canvas) Ellipse(position ecs.Position, dimensions ecs.Dimensions) {
+	var xRadius, yRadius = dimensions.Width / 2, dimensions.Height / 2
+
+	if xRadius <= 0 || yRadius <= 0 {
+		return
+	}
+
+	for y := -yRadius; y <= yRadius; y++ {
+		for x := -xRadius; x <= xRadius; x++ {
+			if ((x*x)/(xRadius*xRadius))+((y*y)/(yRadius*yRadius)) > 1 {
+				continue
+			}
+
+			canvas.Draw(int32(position.X+x), int32(position.Y+y), canvas.fill)
+		}
+	}
+}
+
+// Encode will write the canvas to the writer as a PNG image.
+func (canvas *Canvas) Encode(writer io.Writer) error {
+	return png.Encode(writer, canvas.image)
+}
+
+// Save will write the canvas to a PNG image file at the given path.
+func (canvas *Canvas) Save(path string) error {
+	var file, err = os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	if err = canvas.Encode(file); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+func abs(value int32) int32 {
+	if value < 0 {
+		return -value
+	}
+
+	return value
+}
@@ -0,0 +1,205 @@
+package tween
+
+import "math"
+
+// Easing shapes how a tween moves between it's start and end, given how far
+// through the tween it is from 0 to 1. Most return 0 at the start and 1 at the
+// end, but some overshoot on the way.
+type Easing func(progress float32) float32
+
+// Linear moves at the same speed the whole way.
+func Linear(progress float32) float32 {
+	return progress
+}
+
+// QuadIn starts slowly and speeds up.
+func QuadIn(progress float32) float32 {
+	return progress * progress
+}
+
+// QuadOut starts quickly and slows down.
+func QuadOut(progress float32) float32 {
+	return 1 - QuadIn(1-progress)
+}
+
+// QuadInOut speeds up, then slows down.
+func QuadInOut(progress float32) float32 {
+	return inOut(QuadIn, progress)
+}
+
+// CubicIn starts slowly and speeds up, more sharply than QuadIn.
+func CubicIn(progress float32) float32 {
+	return progress * progress * progress
+}
+
+// CubicOut starts quickly and slows down, more sharply than QuadOut.
+func CubicOut(progress float32) float32 {
+	return 1 - CubicIn(1-progress)
+}
+
+// CubicInOut speeds up, then slows down.
+func CubicInOut(progress float32) float32 {
+	return inOut(CubicIn, progress)
+}
+
+// QuartIn starts slowly and speeds up, more sharply than CubicIn.
+func QuartIn(progress float32) float32 {
+	return progress * progress * progress * progress
+}
+
+// QuartOut starts quickly and slows down, more sharply than CubicOut.
+func QuartOut(progress float32) float32 {
+	return 1 - QuartIn(1-progress)
+}
+
+// QuartInOut speeds up, then slows down.
+func QuartInOut(progress float32) float32 {
+	return inOut(QuartIn, progress)
+}
+
+// QuintIn starts slowly and speeds up, more sharply than QuartIn.
+func QuintIn(progress float32) float32 {
+	return progress * progress * progress * progress * progress
+}
+
+// QuintOut starts quickly and slows down, more sharply than QuartOut.
+func QuintOut(progress float32) float32 {
+	return 1 - QuintIn(1-progress)
+}
+
+// QuintInOut speeds up, then slows down.
+func QuintInOut(progress float32) float32 {
+	return inOut(QuintIn, progress)
+}
+
+// SineIn follows a quarter of a sine wave, starting gently.
+func SineIn(progress float32) float32 {
+	return 1 - float32(math.Cos(float64(progress)*math.Pi/2))
+}
+
+// SineOut follows a quarter of a sine wave, ending gently.
+func SineOut(progress float32) float32 {
+	return float32(math.Sin(float64(progress) * math.Pi / 2))
+}
+
+// SineInOut follows half of a sine wave, starting and ending gently.
+func SineInOut(progress float32) float32 {
+	return inOut(SineIn, progress)
+}
+
+// ExpoIn barely moves until near the end, doubling in speed as it goes.
+func ExpoIn(progress float32) float32 {
+	if 0 == progress {
+		return 0
+	}
+
+	return float32(math.Pow(2, 10*float64(progress)-10))
+}
+
+// ExpoOut covers most of the way at once, then creeps to the end.
+func ExpoOut(progress float32) float32 {
+	return 1 - ExpoIn(1-progress)
+}
+
+// ExpoInOut creeps away from the start and into the end.
+func ExpoInOut(progress float32) float32 {
+	return inOut(ExpoIn, progress)
+}
+
+// CircIn follows a quarter of a circle, starting slowly.
+func CircIn(progress float32) float32 {
+	return 1 - float32(math.Sqrt(1-float64(progress*progress)))
+}
+
+// CircOut follows a quarter of a circle, ending slowly.
+func CircOut(progress float32) float32 {
+	return 1 - CircIn(1-progress)
+}
+
+// CircInOut follows a quarter of a circle at each end.
+func CircInOut(progress float32) float32 {
+	return inOut(CircIn, progress)
+}
+
+// back is how far the back easings overshoot.
+const back = 1.70158
+
+// BackIn pulls back past the start before moving to the end.
+func BackIn(progress float32) float32 {
+	return progress * progress * (((back + 1) * progress) - back)
+}
+
+// BackOut overshoots the end before settling back on it.
+func BackOut(progress float32) float32 {
+	return 1 - BackIn(1-progress)
+}
+
+// BackInOut pulls back past the start, and overshoots the end.
+func BackInOut(progress float32) float32 {
+	return inOut(BackIn, progress)
+}
+
+// ElasticIn winds up like a spring before moving to the end.
+func ElasticIn(progress float32) float32 {
+	return 1 - ElasticOut(1-progress)
+}
+
+// ElasticOut springs past the end, wobbling until it settles on it.
+func ElasticOut(progress float32) float32 {
+	if 0 == progress || 1 == progress {
+		return progress
+	}
+
+	var wobble = math.Sin((float64(progress)*10 - 0.75) * (2 * math.Pi / 3))
+
+	return float32(math.Pow(2, -10*float64(progress))*wobble) + 1
+}
+
+// ElasticInOut winds up at the start, and springs past the end.
+func ElasticInOut(progress float32) float32 {
+	return inOut(ElasticIn, progress)
+}
+
+// BounceIn bounces off the start, higher each time, before moving to the end.
+func BounceIn(progress float32) float32 {
+	return 1 - BounceOut(1-progress)
+}
+
+// BounceOut falls to the end and bounces off it, lower each time.
+func BounceOut(progress float32) float32 {
+	const (
+		strength = 7.5625
+		width    = 2.75
+	)
+
+	switch {
+	case progress < 1/width:
+		return strength * progress * progress
+	case progress < 2/width:
+		progress -= 1.5 / width
+
+		return (strength * progress * progress) + 0.75
+	case progress < 2.5/width:
+		progress -= 2.25 / width
+
+		return (strength * progress * progress) + 0.9375
+	default:
+		progress -= 2.625 / width
+
+		return (strength * progress * progress) + 0.984375
+	}
+}
+
+// BounceInOut bounces off the start, and then off the end.
+func BounceInOut(progress float32) float32 {
+	return inOut(BounceIn, progress)
+}
+
+// inOut plays the easing for the first half, and it's reverse for the second.
+func inOut(easing Easing, progress float32) float32 {
+	if progress < 0.5 {
+		return easing(progress*2) / 2
+	}
+
+	return 1 - (easing((1-progress)*2) / 2)
+}
@@ -0,0 +1,79 @@
+package tween
+
+// Player updates every animation it is playing, and forgets them once they are
+// done. The engine adds one to every world as a resource named "tweens", and
+// updates it each frame by the time the frame took, outside of the fixed step.
+type Player struct {
+	playing []Animation
+}
+
+// CreatePlayer returns a player with nothing playing.
+func CreatePlayer() *Player {
+	return new(Player)
+}
+
+// Name of the tweens resource.
+func (player *Player) Name() string {
+	return "tweens"
+}
+
+// Play will start playing the animation, returning it so that it can be stopped
+// later.
+func (player *Player) Play(animation Animation) Animation {
+	player.playing = append(player.playing, animation)
+
+	return animation
+}
+
+// Stop will stop playing the animation, leaving it's fields where they are.
+func (player *Player) Stop(animation Animation) {
+	for index, playing := range player.playing {
+		if animation == playing {
+			player.playing = append(player.playing[:index], player.playing[index+1:]...)
+
+			return
+		}
+	}
+}
+
+// Playing tells if the animation is still being played.
+func (player *Player) Playing(animation Animation) bool {
+	for _, playing := range player.playing {
+		if animation == playing {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Len returns the number of animations being played.
+func (player *Player) Len() int {
+	return len(player.playing)
+}
+
+// Clear will stop playing every animation.
+func (player *Player) Clear() {
+	player.playing = nil
+}
+
+// Update will advance every animation by the time given. Animations played from
+// a callback during the update start on the next one.
+func (player *Player) Update(dt float32) {
+	// copied, since callbacks may play or stop animations
+	var playing = append([]Animation(nil), player.playing...)
+
+	for _, animation := range playing {
+		animation.Update(dt)
+	}
+
+	var kept = player.playing[:0]
+
+	for _, animation := range player.playing {
+		if !animation.Done() {
+			kept = append(kept, animation)
+		}
+	}
+
+	player.playing = kept
+}
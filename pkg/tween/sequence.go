@@ -0,0 +1,213 @@
+package tween
+
+// Sequence plays animations one after another.
+type Sequence struct {
+	animations []Animation
+	current    int
+	onComplete func()
+	done       bool
+}
+
+// CreateSequence returns a sequence of the animations, in the order given.
+func CreateSequence(animations ...Animation) *Sequence {
+	return &Sequence{animations: animations}
+}
+
+// Then will add the animation to the end of the sequence.
+func (sequence *Sequence) Then(animation Animation) *Sequence {
+	sequence.animations = append(sequence.animations, animation)
+
+	return sequence
+}
+
+// OnComplete sets a function to call when the last animation finishes.
+func (sequence *Sequence) OnComplete(callback func()) *Sequence {
+	sequence.onComplete = callback
+
+	return sequence
+}
+
+// Update will advance the current animation, carrying any time left over once
+// it is done into the next.
+func (sequence *Sequence) Update(dt float32) float32 {
+	if sequence.done {
+		return dt
+	}
+
+	for sequence.current < len(sequence.animations) {
+		var animation = sequence.animations[sequence.current]
+
+		dt = animation.Update(dt)
+
+		if !animation.Done() {
+			return 0
+		}
+
+		sequence.current++
+	}
+
+	sequence.done = true
+
+	if nil != sequence.onComplete {
+		sequence.onComplete()
+	}
+
+	return dt
+}
+
+// Done tells if every animation in the sequence has finished.
+func (sequence *Sequence) Done() bool {
+	return sequence.done
+}
+
+// Reset will rewind every animation in the sequence, and start from the first.
+func (sequence *Sequence) Reset() {
+	for _, animation := range sequence.animations {
+		animation.Reset()
+	}
+
+	sequence.current = 0
+	sequence.done = false
+}
+
+// Group plays animations at the same time, finishing once they all have.
+type Group struct {
+	animations []Animation
+	onComplete func()
+	done       bool
+}
+
+// CreateGroup returns a group of the animations, played in parallel.
+func CreateGroup(animations ...Animation) *Group {
+	return &Group{animations: animations}
+}
+
+// With will add the animation to the group.
+func (group *Group) With(animation Animation) *Group {
+	group.animations = append(group.animations, animation)
+
+	return group
+}
+
+// OnComplete sets a function to call when the last animation finishes.
+func (group *Group) OnComplete(callback func()) *Group {
+	group.onComplete = callback
+
+	return group
+}
+
+// Update will advance every animation still playing. Once they are all done,
+// it returns the time left over after the longest.
+func (group *Group) Update(dt float32) float32 {
+	if group.done {
+		return dt
+	}
+
+	var left = dt
+
+	for _, animation := range group.animations {
+		if animation.Done() {
+			continue
+		}
+
+		var remaining = animation.Update(dt)
+
+		if !animation.Done() {
+			left = 0
+		} else if remaining < left {
+			left = remaining
+		}
+	}
+
+	for _, animation := range group.animations {
+		if !animation.Done() {
+			return 0
+		}
+	}
+
+	group.done = true
+
+	if nil != group.onComplete {
+		group.onComplete()
+	}
+
+	return left
+}
+
+// Done tells if every animation in the group has finished.
+func (group *Group) Done() bool {
+	return group.done
+}
+
+// Reset will rewind every animation in the group.
+func (group *Group) Reset() {
+	for _, animation := range group.animations {
+		animation.Reset()
+	}
+
+	group.done = false
+}
+
+// wait is an animation that does nothing for a while.
+type wait struct {
+	duration float32
+	elapsed  float32
+}
+
+// Wait returns an animation that does nothing for the duration, such as to
+// pause between the steps of a sequence.
+func Wait(duration float32) Animation {
+	return &wait{duration: duration}
+}
+
+func (wait *wait) Update(dt float32) float32 {
+	if wait.elapsed >= wait.duration {
+		return dt
+	}
+
+	wait.elapsed += dt
+
+	if wait.elapsed < wait.duration {
+		return 0
+	}
+
+	return wait.elapsed - wait.duration
+}
+
+func (wait *wait) Done() bool {
+	return wait.elapsed >= wait.duration
+}
+
+func (wait *wait) Reset() {
+	wait.elapsed = 0
+}
+
+// call is an animation that calls a function and is done straight away.
+type call struct {
+	callback func()
+	done     bool
+}
+
+// Call returns an animation that calls the function, such as to play a sound
+// at a step of a sequence.
+func Call(callback func()) Animation {
+	return &call{callback: callback}
+}
+
+func (call *call) Update(dt float32) float32 {
+	if !call.done {
+		call.done = true
+
+		call.callback()
+	}
+
+	return dt
+}
+
+func (call *call) Done() bool {
+	return call.done
+}
+
+func (call *call) Reset() {
+	call.done = false
+}
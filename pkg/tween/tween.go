@@ -0,0 +1,329 @@
+// Package tween animates numeric fields over time, such as a component's
+// position, scale, or colour, easing between their start and end with curves
+// built on maths.Lerp. Tweens can be strung together in sequences, played at
+// the same time in groups, delayed, repeated, and played back and forth.
+package tween
+
+import (
+	"github.com/jordanbrauer/hallucinator/pkg/ecs"
+	"github.com/jordanbrauer/hallucinator/pkg/maths"
+)
+
+// Forever repeats a tween until it is stopped.
+const Forever = -1
+
+// Animation is anything that plays over time: a tween, a sequence, a group, a
+// delay, or a callback.
+type Animation interface {
+	// Update will advance the animation by the time given, in seconds. Once
+	// it is done, it returns the time that was left over.
+	Update(dt float32) float32
+
+	// Done tells if the animation has finished playing.
+	Done() bool
+
+	// Reset will rewind the animation so that it can be played again.
+	Reset()
+}
+
+// target is a field being tweened.
+type target interface {
+	// capture will remember the field's value as the start of the tween.
+	capture()
+
+	// apply will set the field to the value the given distance between the
+	// start and end.
+	apply(distance float32)
+}
+
+// Tween changes one or more fields from their values when it starts to the
+// values given, over a duration.
+type Tween struct {
+	targets  []target
+	duration float32
+	delay    float32
+	easing   Easing
+	repeats  int
+	yoyo     bool
+
+	onStart    func()
+	onUpdate   func()
+	onComplete func()
+
+	waited    float32
+	elapsed   float32
+	remaining int
+	forward   bool
+	started   bool
+	done      bool
+}
+
+// To returns a tween that changes the field to the value over the duration.
+func To(field *float32, to, duration float32) *Tween {
+	return create(duration, &number{field: field, to: to})
+}
+
+// FromTo returns a tween that changes the field from one value to another over
+// the duration, rather than from it's value when the tween starts.
+func FromTo(field *float32, from, to, duration float32) *Tween {
+	return create(duration, &number{field: field, from: from, to: to, fixed: true})
+}
+
+// ToVec2 returns a tween that changes the vector to the value over the
+// duration, such as a position or scale.
+func ToVec2(field *maths.Vec2, to maths.Vec2, duration float32) *Tween {
+	return create(duration, &vec2{field: field, to: to})
+}
+
+// ToVec3 returns a tween that changes the vector to the value over the
+// duration, such as a position or scale.
+func ToVec3(field *maths.Vec3, to maths.Vec3, duration float32) *Tween {
+	return create(duration, &vec3{field: field, to: to})
+}
+
+// ToColour returns a tween that changes the colour to the value over the
+// duration.
+func ToColour(field *ecs.Colour, to ecs.Colour, duration float32) *Tween {
+	return create(duration, &colour{field: field, to: to})
+}
+
+// Custom returns a tween that calls the function with how far between the
+// start and end it is, for animating anything that is not a field.
+func Custom(duration float32, apply func(distance float32)) *Tween {
+	return create(duration, custom(apply))
+}
+
+func create(duration float32, targets ...target) *Tween {
+	var tween = &Tween{targets: targets, duration: duration, easing: Linear}
+
+	tween.Reset()
+
+	return tween
+}
+
+// Ease sets the curve the tween moves along. Defaults to Linear.
+func (tween *Tween) Ease(easing Easing) *Tween {
+	tween.easing = easing
+
+	return tween
+}
+
+// Delay sets how long to wait before the tween starts.
+func (tween *Tween) Delay(delay float32) *Tween {
+	tween.delay = delay
+
+	return tween
+}
+
+// Repeat sets how many more times to play the tween once it reaches the end,
+// or Forever.
+func (tween *Tween) Repeat(times int) *Tween {
+	tween.repeats = times
+	tween.remaining = times
+
+	return tween
+}
+
+// Yoyo plays every other repeat of the tween backwards, so that it moves back
+// and forth.
+func (tween *Tween) Yoyo(enabled bool) *Tween {
+	tween.yoyo = enabled
+
+	return tween
+}
+
+// OnStart sets a function to call when the tween starts, after it's delay.
+func (tween *Tween) OnStart(callback func()) *Tween {
+	tween.onStart = callback
+
+	return tween
+}
+
+// OnUpdate sets a function to call each time the tween changes it's fields.
+func (tween *Tween) OnUpdate(callback func()) *Tween {
+	tween.onUpdate = callback
+
+	return tween
+}
+
+// OnComplete sets a function to call when the tween finishes.
+func (tween *Tween) OnComplete(callback func()) *Tween {
+	tween.onComplete = callback
+
+	return tween
+}
+
+// Update will advance the tween, changing it's fields.
+func (tween *Tween) Update(dt float32) float32 {
+	if tween.done {
+		return dt
+	}
+
+	if tween.waited < tween.delay {
+		tween.waited += dt
+
+		if tween.waited < tween.delay {
+			return 0
+		}
+
+		dt = tween.waited - tween.delay
+		tween.waited = tween.delay
+	}
+
+	if !tween.started {
+		tween.start()
+	}
+
+	tween.elapsed += dt
+
+	for tween.elapsed >= tween.duration {
+		if 0 == tween.remaining || tween.duration <= 0 {
+			var left = tween.elapsed - tween.duration
+
+			tween.finish()
+
+			return left
+		}
+
+		tween.elapsed -= tween.duration
+
+		if tween.remaining > 0 {
+			tween.remaining--
+		}
+
+		if tween.yoyo {
+			tween.forward = !tween.forward
+		}
+	}
+
+	tween.apply(tween.elapsed / tween.duration)
+
+	return 0
+}
+
+// Done tells if the tween has finished, including all of it's repeats.
+func (tween *Tween) Done() bool {
+	return tween.done
+}
+
+// Reset will rewind the tween to before it's delay. The start of each field is
+// taken again when it next starts.
+func (tween *Tween) Reset() {
+	tween.waited = 0
+	tween.elapsed = 0
+	tween.remaining = tween.repeats
+	tween.forward = true
+	tween.started = false
+	tween.done = false
+}
+
+func (tween *Tween) start() {
+	tween.started = true
+
+	for _, target := range tween.targets {
+		target.capture()
+	}
+
+	if nil != tween.onStart {
+		tween.onStart()
+	}
+}
+
+func (tween *Tween) finish() {
+	tween.apply(1)
+
+	tween.done = true
+
+	if nil != tween.onComplete {
+		tween.onComplete()
+	}
+}
+
+// apply will set every field to where the tween is, given how far through the
+// current play it is.
+func (tween *Tween) apply(progress float32) {
+	if !tween.forward {
+		progress = 1 - progress
+	}
+
+	var distance = tween.easing(progress)
+
+	for _, target := range tween.targets {
+		target.apply(distance)
+	}
+
+	if nil != tween.onUpdate {
+		tween.onUpdate()
+	}
+}
+
+type number struct {
+	field    *float32
+	from, to float32
+	fixed    bool
+}
+
+func (number *number) capture() {
+	if !number.fixed {
+		number.from = *number.field
+	}
+}
+
+func (number *number) apply(distance float32) {
+	*number.field = maths.Lerp(number.from, number.to, distance)
+}
+
+type vec2 struct {
+	field    *maths.Vec2
+	from, to maths.Vec2
+}
+
+func (vector *vec2) capture() {
+	vector.from = *vector.field
+}
+
+func (vector *vec2) apply(distance float32) {
+	*vector.field = vector.from.Lerp(vector.to, distance)
+}
+
+type vec3 struct {
+	field    *maths.Vec3
+	from, to maths.Vec3
+}
+
+func (vector *vec3) capture() {
+	vector.from = *vector.field
+}
+
+func (vector *vec3) apply(distance float32) {
+	*vector.field = vector.from.Lerp(vector.to, distance)
+}
+
+type colour struct {
+	field    *ecs.Colour
+	from, to ecs.Colour
+}
+
+func (colour *colour) capture() {
+	colour.from = *colour.field
+}
+
+func (colour *colour) apply(distance float32) {
+	colour.field.Red = channel(colour.from.Red, colour.to.Red, distance)
+	colour.field.Green = channel(colour.from.Green, colour.to.Green, distance)
+	colour.field.Blue = channel(colour.from.Blue, colour.to.Blue, distance)
+}
+
+// channel is the colour channel the distance between two others, kept within
+// range when an easing overshoots.
+func channel(from, to byte, distance float32) byte {
+	return byte(maths.Clamp(maths.Lerp(float32(from), float32(to), distance), 0, 255) + 0.5)
+}
+
+type custom func(distance float32)
+
+func (custom) capture() {}
+
+func (apply custom) apply(distance float32) {
+	apply(distance)
+}